@@ -164,7 +164,7 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			fmt.Printf("[AUTH] Token valid for user: %s\n", claims.Username)
 			// Add username to context for potential use in handlers
 			r.Header.Set("X-Username", claims.Username)
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(WithAdmin(r.Context(), claims.Username)))
 		} else {
 			fmt.Printf("[AUTH] Token claims invalid or expired\n")
 			http.Error(w, "Invalid token", http.StatusUnauthorized)
@@ -0,0 +1,23 @@
+package auth
+
+import "context"
+
+type adminKey struct{}
+
+// WithAdmin returns a copy of ctx that carries the username of an authenticated admin
+func WithAdmin(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, adminKey{}, username)
+}
+
+// AdminFromContext returns the admin AuthMiddleware authenticated for the request.
+// Requests that did not pass AuthMiddleware have none.
+func AdminFromContext(ctx context.Context) (string, bool) {
+	username, ok := ctx.Value(adminKey{}).(string)
+	return username, ok && username != ""
+}
+
+// IsAdmin reports whether the request was made by an authenticated admin
+func IsAdmin(ctx context.Context) bool {
+	_, ok := AdminFromContext(ctx)
+	return ok
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Preview tokens grant read access to a single unpublished blog post or monologue
+const (
+	DefaultPreviewTTL = time.Hour
+	MaxPreviewTTL     = 7 * 24 * time.Hour
+	previewAudience   = "preview"
+)
+
+// ErrInvalidPreviewToken is returned for tokens that are malformed, expired, or were
+// not signed by this server
+var ErrInvalidPreviewToken = errors.New("invalid or expired preview token")
+
+type PreviewClaims struct {
+	// ContentType is the GraphQL type of the content, e.g. BlogPost
+	ContentType string `json:"ctype"`
+	ContentID   string `json:"cid"`
+	jwt.RegisteredClaims
+}
+
+// previewKey is derived from the JWT secret so preview tokens can never pass
+// AuthMiddleware, and admin tokens are not accepted as preview tokens
+func previewKey() []byte {
+	mac := hmac.New(sha256.New, jwtSecret)
+	mac.Write([]byte("preview-token"))
+	return mac.Sum(nil)
+}
+
+// NewPreviewToken signs a token for the content that expires after ttl
+func NewPreviewToken(contentType, contentID string, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	claims := &PreviewClaims{
+		ContentType: contentType,
+		ContentID:   contentID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{previewAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(previewKey())
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign preview token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// ParsePreviewToken verifies a preview token and returns its claims
+func ParsePreviewToken(tokenString string) (*PreviewClaims, error) {
+	claims := &PreviewClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return previewKey(), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(previewAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPreviewToken, err)
+	}
+	return claims, nil
+}
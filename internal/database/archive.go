@@ -0,0 +1,143 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+)
+
+// publishedContent lists the publicly listed blog posts and monologues with their publish
+// dates. Archived posts are left out like in every other list.
+const publishedContent = `
+	SELECT 'BlogPost' AS kind, id, published_at FROM blog_posts
+	WHERE status = 'PUBLISHED' AND published_at IS NOT NULL
+	UNION ALL
+	SELECT 'Monologue' AS kind, id, published_at FROM monologues
+	WHERE is_published = true AND published_at IS NOT NULL
+`
+
+// ContentEntry is a blog post or monologue in a list mixing both, like the content archive.
+// Exactly one of BlogPost and Monologue is set.
+type ContentEntry struct {
+	BlogPost  *models.BlogPost
+	Monologue *models.Monologue
+}
+
+// GetArchiveCounts returns how much content was published in each month, newest first
+func (db *DB) GetArchiveCounts() ([]*models.ArchiveMonth, error) {
+	query := `
+		SELECT EXTRACT(YEAR FROM published_at)::int, EXTRACT(MONTH FROM published_at)::int, COUNT(*)
+		FROM (` + publishedContent + `) content
+		GROUP BY 1, 2
+		ORDER BY 1 DESC, 2 DESC
+	`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archive counts: %w", err)
+	}
+	defer rows.Close()
+
+	var months []*models.ArchiveMonth
+	for rows.Next() {
+		month := &models.ArchiveMonth{}
+		if err := rows.Scan(&month.Year, &month.Month, &month.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan archive count: %w", err)
+		}
+		months = append(months, month)
+	}
+
+	return months, rows.Err()
+}
+
+// GetArchiveEntries returns the content published in [from, to), newest first
+func (db *DB) GetArchiveEntries(from, to time.Time, limit, offset int) ([]*ContentEntry, error) {
+	query := `
+		SELECT kind, id FROM (` + publishedContent + `) content
+		WHERE published_at >= $1 AND published_at < $2
+		ORDER BY published_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	// published_at holds wall-clock times, so the bounds are compared without a zone
+	const layout = "2006-01-02 15:04:05"
+	rows, err := db.Query(query, from.Format(layout), to.Format(layout), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archive: %w", err)
+	}
+	defer rows.Close()
+
+	refs, err := scanContentRefs(rows)
+	if err != nil {
+		return nil, err
+	}
+	return db.loadContent(refs)
+}
+
+// contentRef names a blog post ("BlogPost") or monologue ("Monologue") by kind and ID
+type contentRef struct{ kind, id string }
+
+// scanContentRefs reads (kind, id) rows
+func scanContentRefs(rows *sql.Rows) ([]contentRef, error) {
+	var refs []contentRef
+	for rows.Next() {
+		var ref contentRef
+		if err := rows.Scan(&ref.kind, &ref.id); err != nil {
+			return nil, fmt.Errorf("failed to scan content entry: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// loadContent loads the blog posts and monologues of refs, keeping their order. Refs
+// whose content no longer exists are skipped.
+func (db *DB) loadContent(refs []contentRef) ([]*ContentEntry, error) {
+	var postIDs, monologueIDs []string
+	for _, ref := range refs {
+		if ref.kind == "BlogPost" {
+			postIDs = append(postIDs, ref.id)
+		} else {
+			monologueIDs = append(monologueIDs, ref.id)
+		}
+	}
+
+	posts := map[string]*models.BlogPost{}
+	if len(postIDs) > 0 {
+		list, err := db.queryBlogPosts(`
+			SELECT `+blogPostColumns+` FROM blog_posts WHERE id = ANY($1::uuid[])
+		`, pq.Array(postIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range list {
+			posts[post.ID] = post
+		}
+	}
+
+	monologues := map[string]*models.Monologue{}
+	if len(monologueIDs) > 0 {
+		list, err := db.queryMonologues(`
+			SELECT `+monologueColumns+` FROM monologues m WHERE m.id = ANY($1::uuid[])
+		`, pq.Array(monologueIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, mono := range list {
+			monologues[mono.ID] = mono
+		}
+	}
+
+	var entries []*ContentEntry
+	for _, ref := range refs {
+		entry := &ContentEntry{BlogPost: posts[ref.id], Monologue: monologues[ref.id]}
+		if entry.BlogPost != nil || entry.Monologue != nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,244 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// BulkItem identifies one blog post or monologue in a bulk operation
+type BulkItem struct {
+	BlogPost bool
+	ID       string
+}
+
+func (item BulkItem) table() string {
+	if item.BlogPost {
+		return "blog_posts"
+	}
+	return "monologues"
+}
+
+// BulkResult is the outcome for the item at the same index. Changed is false when the
+// item already was in the requested state.
+type BulkResult struct {
+	Changed bool
+	Err     error
+}
+
+var errBulkNotFound = fmt.Errorf("not found")
+
+// runBulk applies op to every item inside a single transaction. Each item runs in its
+// own savepoint so one failing item is rolled back without aborting the others.
+func (db *DB) runBulk(items []BulkItem, op func(tx *sql.Tx, item BulkItem) (bool, error)) ([]BulkResult, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	results := make([]BulkResult, len(items))
+	for i, item := range items {
+		if _, err := tx.Exec("SAVEPOINT bulk_item"); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		changed, err := op(tx, item)
+		if err != nil {
+			if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT bulk_item"); rbErr != nil {
+				return nil, fmt.Errorf("failed to roll back savepoint: %w", rbErr)
+			}
+			results[i] = BulkResult{Err: err}
+			continue
+		}
+
+		if _, err := tx.Exec("RELEASE SAVEPOINT bulk_item"); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		results[i] = BulkResult{Changed: changed}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return results, nil
+}
+
+// BlogPostIDs returns which of ids belong to blog posts. A bare UUID does not say
+// whether it refers to a blog post or a monologue.
+func (db *DB) BlogPostIDs(ids []string) (map[string]bool, error) {
+	found := map[string]bool{}
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	rows, err := db.Query("SELECT id FROM blog_posts WHERE id = ANY($1::uuid[])", pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blog post ids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan blog post id: %w", err)
+		}
+		found[id] = true
+	}
+	return found, rows.Err()
+}
+
+// bulkChanged turns an UPDATE that matched no rows into either "unchanged" or "not found"
+func bulkChanged(tx *sql.Tx, item BulkItem, result sql.Result) (bool, error) {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected > 0 {
+		return true, nil
+	}
+
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)", item.table())
+	if err := tx.QueryRow(query, item.ID).Scan(&exists); err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, errBulkNotFound
+	}
+
+	return false, nil
+}
+
+func (db *DB) BulkPublish(items []BulkItem) ([]BulkResult, error) {
+	now := time.Now().Format(time.RFC3339)
+
+	return db.runBulk(items, func(tx *sql.Tx, item BulkItem) (bool, error) {
+		query := `
+			UPDATE monologues
+			SET is_published = true, published_at = $1, scheduled_at = NULL, updated_at = NOW()
+			WHERE id = $2 AND is_published = false
+		`
+		if item.BlogPost {
+			query = `
+				UPDATE blog_posts
+				SET status = 'PUBLISHED', published_at = COALESCE(published_at, $1), scheduled_at = NULL, updated_at = NOW()
+				WHERE id = $2 AND status <> 'PUBLISHED'
+			`
+		}
+
+		result, err := tx.Exec(query, now, item.ID)
+		if err != nil {
+			return false, err
+		}
+		if !item.BlogPost {
+			if err := ensurePublishable(tx, item.ID); err != nil {
+				return false, err
+			}
+		}
+		return bulkChanged(tx, item, result)
+	})
+}
+
+func (db *DB) BulkUnpublish(items []BulkItem) ([]BulkResult, error) {
+	return db.runBulk(items, func(tx *sql.Tx, item BulkItem) (bool, error) {
+		query := `
+			UPDATE monologues
+			SET is_published = false, published_at = NULL, updated_at = NOW()
+			WHERE id = $1 AND is_published = true
+		`
+		if item.BlogPost {
+			query = `
+				UPDATE blog_posts
+				SET status = 'DRAFT', updated_at = NOW()
+				WHERE id = $1 AND status = 'PUBLISHED'
+			`
+		}
+
+		result, err := tx.Exec(query, item.ID)
+		if err != nil {
+			return false, err
+		}
+		if !item.BlogPost {
+			if err := unpublishReplies(tx, item.ID); err != nil {
+				return false, err
+			}
+		}
+		return bulkChanged(tx, item, result)
+	})
+}
+
+func (db *DB) BulkDelete(items []BulkItem) ([]BulkResult, error) {
+	return db.runBulk(items, func(tx *sql.Tx, item BulkItem) (bool, error) {
+		if !item.BlogPost {
+			if err := detachReplies(tx, item.ID); err != nil {
+				return false, err
+			}
+		}
+		result, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = $1", item.table()), item.ID)
+		if err != nil {
+			return false, err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		if rowsAffected == 0 {
+			return false, errBulkNotFound
+		}
+		return true, nil
+	})
+}
+
+// BulkAddTags appends the tags an item does not have yet, keeping the existing order
+func (db *DB) BulkAddTags(items []BulkItem, tags []string) ([]BulkResult, error) {
+	return db.runBulk(items, func(tx *sql.Tx, item BulkItem) (bool, error) {
+		query := fmt.Sprintf(`
+			UPDATE %s
+			SET tags = COALESCE(tags, '{}') || ARRAY(
+					SELECT t FROM unnest($1::text[]) AS t
+					WHERE NOT t = ANY(COALESCE(tags, '{}'))
+				),
+				updated_at = NOW()
+			WHERE id = $2 AND NOT COALESCE(tags, '{}') @> $1::text[]
+		`, item.table())
+
+		result, err := tx.Exec(query, pq.Array(tags), item.ID)
+		if err != nil {
+			return false, err
+		}
+		if err := refreshTagKeys(tx, item.table(), []string{item.ID}); err != nil {
+			return false, err
+		}
+		return bulkChanged(tx, item, result)
+	})
+}
+
+// BulkRemoveTags removes the given tags from every item, keeping the remaining order
+func (db *DB) BulkRemoveTags(items []BulkItem, tags []string) ([]BulkResult, error) {
+	return db.runBulk(items, func(tx *sql.Tx, item BulkItem) (bool, error) {
+		query := fmt.Sprintf(`
+			UPDATE %s
+			SET tags = ARRAY(
+					SELECT t FROM unnest(tags) WITH ORDINALITY AS u(t, i)
+					WHERE NOT t = ANY($1::text[])
+					ORDER BY i
+				),
+				updated_at = NOW()
+			WHERE id = $2 AND tags && $1::text[]
+		`, item.table())
+
+		result, err := tx.Exec(query, pq.Array(tags), item.ID)
+		if err != nil {
+			return false, err
+		}
+		if err := refreshTagKeys(tx, item.table(), []string{item.ID}); err != nil {
+			return false, err
+		}
+		return bulkChanged(tx, item, result)
+	})
+}
@@ -0,0 +1,352 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/slug"
+)
+
+var (
+	ErrCodeCategoryNotFound       = errors.New("code category not found")
+	ErrCodeCategoryParentNotFound = errors.New("parent code category not found")
+	ErrCodeCategorySlugTaken      = errors.New("another code category already uses this slug")
+	ErrCodeCategoryCycle          = errors.New("a code category cannot be moved below itself or one of its descendants")
+)
+
+// categorySubtree selects the ID of category %s and of all its descendants
+const categorySubtree = `
+	WITH RECURSIVE subtree AS (
+		SELECT id FROM code_categories WHERE id = %s
+		UNION
+		SELECT c.id FROM code_categories c JOIN subtree s ON c.parent_id = s.id
+	)
+	SELECT id FROM subtree
+`
+
+// GetCodeCategories returns the root categories by name, with their descendants in Children
+func (db *DB) GetCodeCategories() ([]*models.CodeCategory, error) {
+	roots, _, err := db.codeCategoryTree("TRUE")
+	return roots, err
+}
+
+// GetCodeCategoryMap returns every category by ID, linked into the same tree as
+// GetCodeCategories
+func (db *DB) GetCodeCategoryMap() (map[string]*models.CodeCategory, error) {
+	_, byID, err := db.codeCategoryTree("TRUE")
+	return byID, err
+}
+
+// GetCodeCategoryByID returns nil when the category does not exist. Only the category and
+// its descendants are loaded.
+func (db *DB) GetCodeCategoryByID(id string) (*models.CodeCategory, error) {
+	_, byID, err := db.codeCategoryTree("c.id IN ("+fmt.Sprintf(categorySubtree, "$1")+")", id)
+	if err != nil {
+		return nil, err
+	}
+	return byID[id], nil
+}
+
+// GetCodeCategoryBySlug returns nil when no category has the slug. Only the category and
+// its descendants are loaded.
+func (db *DB) GetCodeCategoryBySlug(categorySlug string) (*models.CodeCategory, error) {
+	root := "(SELECT id FROM code_categories WHERE slug = $1)"
+	roots, _, err := db.codeCategoryTree("c.id IN ("+fmt.Sprintf(categorySubtree, root)+")", categorySlug)
+	if err != nil || len(roots) == 0 {
+		return nil, err
+	}
+	return roots[0], nil
+}
+
+// codeCategoryTree loads the categories matching where with their count of published
+// monologues and links them into a tree. Categories whose parent is not loaded are roots,
+// so a subtree comes back with its top category as the only root.
+func (db *DB) codeCategoryTree(where string, args ...interface{}) ([]*models.CodeCategory, map[string]*models.CodeCategory, error) {
+	query := `
+		SELECT c.id, c.name, c.slug, c.description, c.parent_id, c.color, c.icon,
+		       c.created_at, c.updated_at, COUNT(m.id)
+		FROM code_categories c
+		LEFT JOIN monologues m ON m.code_category_id = c.id AND m.is_published = true
+		WHERE ` + where + `
+		GROUP BY c.id
+		ORDER BY c.name, c.slug
+	`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query code categories: %w", err)
+	}
+	defer rows.Close()
+
+	var list []*models.CodeCategory
+	for rows.Next() {
+		category := &models.CodeCategory{Children: []*models.CodeCategory{}}
+		var description, parentID, color, icon sql.NullString
+		err := rows.Scan(
+			&category.ID, &category.Name, &category.Slug, &description, &parentID,
+			&color, &icon, &category.CreatedAt, &category.UpdatedAt, &category.Count,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan code category: %w", err)
+		}
+		category.Description = nullStringToPtr(description)
+		category.ParentID = nullStringToPtr(parentID)
+		category.Color = nullStringToPtr(color)
+		category.Icon = nullStringToPtr(icon)
+		list = append(list, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[string]*models.CodeCategory, len(list))
+	for _, category := range list {
+		byID[category.ID] = category
+	}
+
+	roots := []*models.CodeCategory{}
+	for _, category := range list {
+		if parent := parentOf(category, byID); parent != nil {
+			parent.Children = append(parent.Children, category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	for _, root := range roots {
+		sumCodeCategoryCounts(root)
+	}
+
+	return roots, byID, nil
+}
+
+// parentOf returns the parent of category, or nil for roots
+func parentOf(category *models.CodeCategory, byID map[string]*models.CodeCategory) *models.CodeCategory {
+	if category.ParentID == nil {
+		return nil
+	}
+	return byID[*category.ParentID]
+}
+
+// sumCodeCategoryCounts sets TotalCount of category and its descendants
+func sumCodeCategoryCounts(category *models.CodeCategory) int {
+	category.TotalCount = category.Count
+	for _, child := range category.Children {
+		category.TotalCount += sumCodeCategoryCounts(child)
+	}
+	return category.TotalCount
+}
+
+// CreateCodeCategory stores a new category. The slug is generated from the name when input
+// has none.
+func (db *DB) CreateCodeCategory(input models.CreateCodeCategoryInput) (*models.CodeCategory, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if input.ParentID != nil {
+		if err := checkCodeCategoryParent(tx, "", *input.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	categorySlug := input.Slug
+	if categorySlug == nil {
+		generated, err := uniqueCodeCategorySlug(tx, slug.Generate(input.Name))
+		if err != nil {
+			return nil, err
+		}
+		categorySlug = &generated
+	}
+
+	query := `
+		INSERT INTO code_categories (name, slug, description, parent_id, color, icon)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	var id string
+	err = tx.QueryRow(query,
+		input.Name, *categorySlug, ptrToNullString(input.Description),
+		ptrToNullString(input.ParentID), ptrToNullString(input.Color), ptrToNullString(input.Icon),
+	).Scan(&id)
+	if isUniqueViolation(err) {
+		return nil, ErrCodeCategorySlugTaken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create code category: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit code category: %w", err)
+	}
+
+	return db.GetCodeCategoryByID(id)
+}
+
+// UpdateCodeCategory applies the set fields of input. Empty strings clear the optional
+// fields, an empty parentId makes the category a root. Moving a category below itself or
+// one of its descendants fails with ErrCodeCategoryCycle.
+func (db *DB) UpdateCodeCategory(id string, input models.UpdateCodeCategoryInput) (*models.CodeCategory, error) {
+	setParts := []string{"updated_at = NOW()"}
+	args := []interface{}{}
+	argIndex := 1
+
+	if input.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, *input.Name)
+		argIndex++
+	}
+	if input.Slug != nil {
+		setParts = append(setParts, fmt.Sprintf("slug = $%d", argIndex))
+		args = append(args, *input.Slug)
+		argIndex++
+	}
+	for _, field := range []struct {
+		column string
+		value  *string
+	}{
+		{"description", input.Description},
+		{"color", input.Color},
+		{"icon", input.Icon},
+	} {
+		if field.value != nil {
+			setParts = append(setParts, fmt.Sprintf("%s = NULLIF($%d, '')", field.column, argIndex))
+			args = append(args, *field.value)
+			argIndex++
+		}
+	}
+	if input.ParentID != nil {
+		setParts = append(setParts, fmt.Sprintf("parent_id = NULLIF($%d, '')::uuid", argIndex))
+		args = append(args, *input.ParentID)
+		argIndex++
+	}
+
+	query := fmt.Sprintf("UPDATE code_categories SET %s WHERE id = $%d", joinStrings(setParts, ", "), argIndex)
+	args = append(args, id)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if input.ParentID != nil && *input.ParentID != "" {
+		// Concurrent moves could otherwise form a cycle that neither check sees
+		if _, err := tx.Exec("LOCK TABLE code_categories IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return nil, fmt.Errorf("failed to lock code categories: %w", err)
+		}
+		if err := checkCodeCategoryParent(tx, id, *input.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := tx.Exec(query, args...)
+	if isUniqueViolation(err) {
+		return nil, ErrCodeCategorySlugTaken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update code category: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrCodeCategoryNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit code category: %w", err)
+	}
+
+	return db.GetCodeCategoryByID(id)
+}
+
+// checkCodeCategoryParent fails when parentID does not exist or is id or one of its
+// descendants. New categories pass an empty id.
+func checkCodeCategoryParent(tx *sql.Tx, id, parentID string) error {
+	var exists, cycle bool
+	err := tx.QueryRow(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM code_categories WHERE id = $1
+			UNION
+			SELECT c.id, c.parent_id FROM code_categories c JOIN ancestors a ON c.id = a.parent_id
+		)
+		SELECT EXISTS(SELECT 1 FROM ancestors), EXISTS(SELECT 1 FROM ancestors WHERE id::text = $2)
+	`, parentID, id).Scan(&exists, &cycle)
+	if err != nil {
+		return fmt.Errorf("failed to check code category parent: %w", err)
+	}
+	if !exists {
+		return ErrCodeCategoryParentNotFound
+	}
+	if cycle {
+		return ErrCodeCategoryCycle
+	}
+	return nil
+}
+
+// DeleteCodeCategory removes a category. Its children move up to its parent and its
+// monologues are left without a category.
+func (db *DB) DeleteCodeCategory(id string) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE code_categories
+		SET parent_id = (SELECT parent_id FROM code_categories WHERE id = $1), updated_at = NOW()
+		WHERE parent_id = $1
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to move child categories: %w", err)
+	}
+
+	if _, err := tx.Exec("UPDATE monologues SET code_category_id = NULL WHERE code_category_id = $1", id); err != nil {
+		return false, fmt.Errorf("failed to detach monologues: %w", err)
+	}
+
+	result, err := tx.Exec("DELETE FROM code_categories WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete code category: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit code category: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// uniqueCodeCategorySlug returns base, or base with the first free numeric suffix
+func uniqueCodeCategorySlug(tx *sql.Tx, base string) (string, error) {
+	candidate := base
+	for n := 2; ; n++ {
+		var taken bool
+		err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM code_categories WHERE slug = $1)", candidate).Scan(&taken)
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug: %w", err)
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = slug.WithSuffix(base, n)
+	}
+}
+
+// difficultyValue stores an unset difficulty as NULL
+func difficultyValue(d *models.Difficulty) sql.NullString {
+	if d == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(*d), Valid: true}
+}
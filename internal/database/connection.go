@@ -6,10 +6,16 @@ import (
 	"os"
 
 	_ "github.com/lib/pq"
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/postlinks"
+	"github.com/naoya0117/portfolio-v2025-api/internal/textutil"
+	"github.com/naoya0117/portfolio-v2025-api/internal/urlpreview"
 )
 
 type DB struct {
 	*sql.DB
+	// PostLinks detects links to blog posts in monologue content. Nil disables detection.
+	PostLinks *postlinks.Detector
 }
 
 func NewConnection() (*DB, error) {
@@ -40,7 +46,7 @@ func NewConnection() (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{db}, nil
+	return &DB{DB: db}, nil
 }
 
 func (db *DB) Close() error {
@@ -50,6 +56,12 @@ func (db *DB) Close() error {
 	return nil
 }
 
+// The documents stored as tsv, the lexemes of the text related content is matched by
+const (
+	blogPostDocument  = `to_tsvector('simple'::regconfig, title || ' ' || COALESCE(excerpt, '') || ' ' || LEFT(content, 2000))`
+	monologueDocument = `to_tsvector('simple'::regconfig, LEFT(content, 2000))`
+)
+
 // CreateTables creates the necessary tables if they don't exist
 func (db *DB) MigrateTables() error {
 	// Add like_count column to existing blog_posts table if it doesn't exist
@@ -70,6 +82,345 @@ func (db *DB) MigrateTables() error {
 		return fmt.Errorf("failed to add like_count column to monologues: %w", err)
 	}
 
+	// Add reading statistics columns, computed when content is written
+	for _, table := range []string{"blog_posts", "monologues"} {
+		_, err = db.Exec(fmt.Sprintf(`
+			ALTER TABLE %s
+			ADD COLUMN IF NOT EXISTS reading_time INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS character_count INTEGER NOT NULL DEFAULT 0
+		`, table))
+		if err != nil {
+			return fmt.Errorf("failed to add reading statistics columns to %s: %w", table, err)
+		}
+
+		if err := db.backfillTextStats(table); err != nil {
+			return fmt.Errorf("failed to backfill reading statistics for %s: %w", table, err)
+		}
+	}
+
+	// Record failed refreshes without discarding the stored preview
+	_, err = db.Exec(`ALTER TABLE link_previews ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP`)
+	if err != nil {
+		return fmt.Errorf("failed to add failed_at column to link_previews: %w", err)
+	}
+
+	if err := db.migrateURLPreviews(); err != nil {
+		return fmt.Errorf("failed to migrate url_previews: %w", err)
+	}
+
+	// Add scheduled publishing columns
+	for _, table := range []string{"blog_posts", "monologues"} {
+		_, err = db.Exec(fmt.Sprintf(`
+			ALTER TABLE %s
+			ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMPTZ
+		`, table))
+		if err != nil {
+			return fmt.Errorf("failed to add scheduled_at column to %s: %w", table, err)
+		}
+
+		// Created here rather than in CreateTables, which runs before the column exists
+		_, err = db.Exec(fmt.Sprintf(`
+			CREATE INDEX IF NOT EXISTS idx_%s_scheduled_at ON %s (scheduled_at)
+			WHERE scheduled_at IS NOT NULL
+		`, table, table))
+		if err != nil {
+			return fmt.Errorf("failed to index scheduled_at of %s: %w", table, err)
+		}
+	}
+
+	// Add image placeholder columns to media uploaded before they were computed
+	_, err = db.Exec(`
+		ALTER TABLE media
+		ADD COLUMN IF NOT EXISTS blurhash VARCHAR(100),
+		ADD COLUMN IF NOT EXISTS dominant_color VARCHAR(7)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add placeholder columns to media: %w", err)
+	}
+
+	// Add reply threads to monologues
+	_, err = db.Exec(`
+		ALTER TABLE monologues
+		ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES monologues(id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add parent_id column to monologues: %w", err)
+	}
+	_, err = db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_monologues_parent_id ON monologues (parent_id)
+		WHERE parent_id IS NOT NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to index parent_id of monologues: %w", err)
+	}
+
+	if err := db.migrateMonologueSeries(); err != nil {
+		return fmt.Errorf("failed to migrate monologue series: %w", err)
+	}
+
+	// Store the slug of every tag next to the tags, so content is found by tag slug
+	for _, table := range []string{"blog_posts", "monologues"} {
+		_, err = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS tag_keys TEXT[]`, table))
+		if err != nil {
+			return fmt.Errorf("failed to add tag_keys column to %s: %w", table, err)
+		}
+		_, err = db.Exec(fmt.Sprintf(`
+			CREATE INDEX IF NOT EXISTS idx_%s_tag_keys ON %s USING GIN (tag_keys)
+		`, table, table))
+		if err != nil {
+			return fmt.Errorf("failed to index tag_keys of %s: %w", table, err)
+		}
+
+		if err := db.backfillTagKeys(table); err != nil {
+			return fmt.Errorf("failed to backfill tag keys for %s: %w", table, err)
+		}
+	}
+
+	// Store the lexemes related content is matched by. The simple configuration does not
+	// stem, which suits content mixing Japanese and English.
+	for _, column := range []struct{ table, document string }{
+		{"blog_posts", blogPostDocument},
+		{"monologues", monologueDocument},
+	} {
+		_, err = db.Exec(fmt.Sprintf(`
+			ALTER TABLE %s
+			ADD COLUMN IF NOT EXISTS tsv TSVECTOR GENERATED ALWAYS AS (%s) STORED
+		`, column.table, column.document))
+		if err != nil {
+			return fmt.Errorf("failed to add tsv column to %s: %w", column.table, err)
+		}
+		_, err = db.Exec(fmt.Sprintf(`
+			CREATE INDEX IF NOT EXISTS idx_%s_tsv ON %s USING GIN (tsv)
+		`, column.table, column.table))
+		if err != nil {
+			return fmt.Errorf("failed to index tsv of %s: %w", column.table, err)
+		}
+	}
+
+	// Posts written before translations existed are in Japanese
+	_, err = db.Exec(`
+		ALTER TABLE blog_posts
+		ADD COLUMN IF NOT EXISTS locale VARCHAR(35) NOT NULL DEFAULT 'ja'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add locale column to blog_posts: %w", err)
+	}
+
+	return nil
+}
+
+// migrateMonologueSeries turns the free-form series names of monologues into series,
+// ordered by publish date, and drops the old column
+func (db *DB) migrateMonologueSeries() error {
+	var exists bool
+	err := db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_name = 'monologues' AND column_name = 'series'
+		)
+	`).Scan(&exists)
+	if err != nil || !exists {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, btrim(series) FROM monologues
+		WHERE btrim(series) <> ''
+		ORDER BY COALESCE(published_at, created_at), created_at
+	`)
+	if err != nil {
+		return err
+	}
+
+	type member struct{ id, title string }
+	var members []member
+	for rows.Next() {
+		var m member
+		if err := rows.Scan(&m.id, &m.title); err != nil {
+			rows.Close()
+			return err
+		}
+		members = append(members, m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	titles := map[string]bool{}
+	for _, m := range members {
+		if err := joinSeriesByTitle(tx, m.id, m.title); err != nil {
+			return err
+		}
+		titles[m.title] = true
+	}
+
+	if _, err := tx.Exec("ALTER TABLE monologues DROP COLUMN series"); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	fmt.Printf("[MIGRATE] Moved %d monologues into %d series\n", len(members), len(titles))
+	return nil
+}
+
+// migrateURLPreviews moves the per-monologue url_previews rows into the shared
+// link_previews cache and drops the old table, in one transaction so an interrupted
+// migration is run again in full. The moved rows are marked expired so they are served
+// while being refreshed.
+func (db *DB) migrateURLPreviews() error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRow("SELECT to_regclass('url_previews') IS NOT NULL").Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT title, description, image_url, site_name, url, favicon, created_at
+		FROM url_previews ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string]*urlpreview.Entry)
+	for rows.Next() {
+		preview := &models.URLPreview{}
+		var description, imageURL, siteName, favicon sql.NullString
+		err := rows.Scan(
+			&preview.Title, &description, &imageURL, &siteName,
+			&preview.URL, &favicon, &preview.CreatedAt,
+		)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		preview.Description = nullStringToPtr(description)
+		preview.ImageURL = nullStringToPtr(imageURL)
+		preview.SiteName = nullStringToPtr(siteName)
+		preview.Favicon = nullStringToPtr(favicon)
+
+		key, err := urlpreview.Normalize(preview.URL)
+		if _, seen := entries[key]; err != nil || seen {
+			continue
+		}
+		entries[key] = &urlpreview.Entry{Preview: preview, FetchedAt: preview.CreatedAt, ExpiresAt: preview.CreatedAt}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for key, entry := range entries {
+		if err := saveURLPreviewEntry(tx, key, entry); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec("DROP TABLE url_previews"); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit url preview migration: %w", err)
+	}
+
+	fmt.Printf("[MIGRATE] Moved %d URL previews to link_previews\n", len(entries))
+	return nil
+}
+
+// backfillTextStats computes the reading statistics of rows written before the columns
+// existed. Every written row has a reading_time of at least 1, so 0 marks missing values.
+func (db *DB) backfillTextStats(table string) error {
+	rows, err := db.Query(fmt.Sprintf("SELECT id, content FROM %s WHERE reading_time = 0", table))
+	if err != nil {
+		return err
+	}
+
+	type pending struct {
+		id    string
+		stats textutil.Stats
+	}
+	var updates []pending
+	for rows.Next() {
+		var id, content string
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return err
+		}
+		updates = append(updates, pending{id: id, stats: textutil.Analyze(content)})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET reading_time = $1, character_count = $2 WHERE id = $3", table)
+	for _, update := range updates {
+		if _, err := db.Exec(query, update.stats.ReadingMinutes(), update.stats.Characters, update.id); err != nil {
+			return err
+		}
+	}
+
+	if len(updates) > 0 {
+		fmt.Printf("[MIGRATE] Computed reading statistics for %d rows in %s\n", len(updates), table)
+	}
+
+	return nil
+}
+
+// backfillTagKeys computes tag_keys for rows written before the column existed
+func (db *DB) backfillTagKeys(table string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var ids []string
+	rows, err := tx.Query(fmt.Sprintf("SELECT id FROM %s WHERE tag_keys IS NULL", table))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if err := refreshTagKeys(tx, table, ids); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if len(ids) > 0 {
+		fmt.Printf("[MIGRATE] Computed tag keys for %d rows in %s\n", len(ids), table)
+	}
+
 	return nil
 }
 
@@ -137,27 +488,35 @@ func (db *DB) CreateTables() error {
 			code_language VARCHAR(50),
 			code_snippet TEXT,
 			tags TEXT[],
+			tag_keys TEXT[],
 			is_published BOOLEAN DEFAULT FALSE,
 			published_at TIMESTAMP,
 			url VARCHAR(2048),
-			series VARCHAR(255),
 			category VARCHAR(255),
 			code_category_id UUID REFERENCES code_categories(id),
 			difficulty VARCHAR(20) CHECK (difficulty IN ('BEGINNER', 'INTERMEDIATE', 'ADVANCED')),
+			parent_id UUID REFERENCES monologues(id) ON DELETE SET NULL,
 			like_count INTEGER DEFAULT 0,
+			reading_time INTEGER NOT NULL DEFAULT 0,
+			character_count INTEGER NOT NULL DEFAULT 0,
+			scheduled_at TIMESTAMPTZ,
+			tsv TSVECTOR GENERATED ALWAYS AS (` + monologueDocument + `) STORED,
 			created_at TIMESTAMP DEFAULT NOW(),
 			updated_at TIMESTAMP DEFAULT NOW()
 		)`,
 		
-		`CREATE TABLE IF NOT EXISTS url_previews (
-			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-			monologue_id UUID REFERENCES monologues(id),
-			title VARCHAR(500) NOT NULL,
+		`CREATE TABLE IF NOT EXISTS link_previews (
+			normalized_url TEXT PRIMARY KEY,
+			url TEXT NOT NULL,
+			title TEXT,
 			description TEXT,
-			image_url VARCHAR(2048),
-			site_name VARCHAR(200),
-			url VARCHAR(2048) NOT NULL,
-			favicon VARCHAR(2048),
+			image_url TEXT,
+			site_name TEXT,
+			favicon TEXT,
+			error TEXT,
+			fetched_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			expires_at TIMESTAMP NOT NULL,
+			failed_at TIMESTAMP,
 			created_at TIMESTAMP DEFAULT NOW()
 		)`,
 		
@@ -169,11 +528,31 @@ func (db *DB) CreateTables() error {
 			content TEXT NOT NULL,
 			cover_image_url VARCHAR(2048),
 			tags TEXT[],
+			tag_keys TEXT[],
 			status VARCHAR(20) NOT NULL CHECK (status IN ('DRAFT', 'PUBLISHED', 'ARCHIVED')),
 			seo_title VARCHAR(500),
 			seo_description TEXT,
 			published_at TIMESTAMP,
 			like_count INTEGER DEFAULT 0,
+			reading_time INTEGER NOT NULL DEFAULT 0,
+			character_count INTEGER NOT NULL DEFAULT 0,
+			scheduled_at TIMESTAMPTZ,
+			locale VARCHAR(35) NOT NULL DEFAULT 'ja',
+			tsv TSVECTOR GENERATED ALWAYS AS (` + blogPostDocument + `) STORED,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW()
+		)`,
+		
+		`CREATE TABLE IF NOT EXISTS blog_post_slug_history (
+			slug VARCHAR(500) PRIMARY KEY,
+			blog_post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			created_at TIMESTAMP DEFAULT NOW()
+		)`,
+		
+		`CREATE TABLE IF NOT EXISTS tags (
+			slug VARCHAR(100) PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			description TEXT,
 			created_at TIMESTAMP DEFAULT NOW(),
 			updated_at TIMESTAMP DEFAULT NOW()
 		)`,
@@ -186,6 +565,88 @@ func (db *DB) CreateTables() error {
 			created_at TIMESTAMP DEFAULT NOW(),
 			UNIQUE(monologue_id, user_ip)
 		)`,
+		
+		`CREATE TABLE IF NOT EXISTS media (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			storage_key VARCHAR(1024) NOT NULL UNIQUE,
+			filename VARCHAR(255) NOT NULL,
+			content_type VARCHAR(100) NOT NULL,
+			size INTEGER NOT NULL,
+			width INTEGER NOT NULL,
+			height INTEGER NOT NULL,
+			alt_text TEXT,
+			blurhash VARCHAR(100),
+			dominant_color VARCHAR(7),
+			created_at TIMESTAMP DEFAULT NOW()
+		)`,
+		
+		`CREATE TABLE IF NOT EXISTS media_variants (
+			media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+			name VARCHAR(50) NOT NULL,
+			storage_key VARCHAR(1024) NOT NULL UNIQUE,
+			content_type VARCHAR(100) NOT NULL,
+			width INTEGER NOT NULL,
+			height INTEGER NOT NULL,
+			size INTEGER NOT NULL,
+			PRIMARY KEY (media_id, name)
+		)`,
+		
+		`CREATE TABLE IF NOT EXISTS monologue_images (
+			monologue_id UUID NOT NULL REFERENCES monologues(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			media_id UUID REFERENCES media(id) ON DELETE SET NULL,
+			url VARCHAR(2048) NOT NULL,
+			alt_text TEXT,
+			width INTEGER,
+			height INTEGER,
+			blurhash VARCHAR(100),
+			dominant_color VARCHAR(7),
+			caption TEXT,
+			PRIMARY KEY (monologue_id, position)
+		)`,
+		
+		`CREATE TABLE IF NOT EXISTS series (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			slug VARCHAR(255) UNIQUE NOT NULL,
+			title VARCHAR(255) NOT NULL,
+			description TEXT,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW()
+		)`,
+		
+		`CREATE TABLE IF NOT EXISTS series_items (
+			series_id UUID NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			blog_post_id UUID UNIQUE REFERENCES blog_posts(id) ON DELETE CASCADE,
+			monologue_id UUID UNIQUE REFERENCES monologues(id) ON DELETE CASCADE,
+			PRIMARY KEY (series_id, position),
+			CHECK ((blog_post_id IS NULL) <> (monologue_id IS NULL))
+		)`,
+		
+		`CREATE TABLE IF NOT EXISTS monologue_blog_posts (
+			monologue_id UUID NOT NULL REFERENCES monologues(id) ON DELETE CASCADE,
+			blog_post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			detected BOOLEAN NOT NULL DEFAULT false,
+			created_at TIMESTAMP DEFAULT NOW(),
+			PRIMARY KEY (monologue_id, blog_post_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_monologue_blog_posts_blog_post_id ON monologue_blog_posts(blog_post_id)`,
+		
+		`CREATE TABLE IF NOT EXISTS blog_post_translations (
+			blog_post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			locale VARCHAR(35) NOT NULL,
+			title VARCHAR(500) NOT NULL,
+			excerpt TEXT,
+			content TEXT NOT NULL,
+			seo_title VARCHAR(500),
+			seo_description TEXT,
+			reading_time INTEGER NOT NULL DEFAULT 0,
+			character_count INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW(),
+			PRIMARY KEY (blog_post_id, locale)
+		)`,
 	}
 
 	for _, query := range queries {
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+)
+
+// Monologues link to blog posts through monologue_blog_posts. Links are either attached by
+// the author or detected from post URLs in the monologue's content; attached links come
+// first and stay when the content stops mentioning the post.
+
+// GetRelatedBlogPosts returns the published and archived posts a monologue links to,
+// attached posts first
+func (db *DB) GetRelatedBlogPosts(monologueID string) ([]*models.BlogPost, error) {
+	query := `
+		SELECT ` + blogPostColumns + `
+		FROM blog_posts
+		JOIN (
+			SELECT blog_post_id, detected, position FROM monologue_blog_posts WHERE monologue_id = $1
+		) l ON l.blog_post_id = blog_posts.id
+		WHERE status IN ('PUBLISHED', 'ARCHIVED')
+		ORDER BY l.detected, l.position
+	`
+
+	posts, err := db.queryBlogPosts(query, monologueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query related blog posts: %w", err)
+	}
+	return posts, nil
+}
+
+// GetMentioningMonologues returns the published monologues that link to a blog post,
+// newest first
+func (db *DB) GetMentioningMonologues(blogPostID string) ([]*models.Monologue, error) {
+	query := `
+		SELECT ` + monologueColumns + `
+		FROM monologues m
+		JOIN monologue_blog_posts l ON l.monologue_id = m.id
+		WHERE l.blog_post_id = $1 AND m.is_published = true
+		ORDER BY m.published_at DESC, m.created_at DESC
+	`
+
+	monologues, err := db.queryMonologues(query, blogPostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mentioning monologues: %w", err)
+	}
+	return monologues, nil
+}
+
+// linkMonologuesTo detects the links of the monologues mentioning postSlug again, after a
+// post was created with the slug or moved to it
+func (db *DB) linkMonologuesTo(tx *sql.Tx, postSlug string) error {
+	if db.PostLinks == nil {
+		return nil
+	}
+
+	rows, err := tx.Query("SELECT id FROM monologues WHERE strpos(content, $1) > 0", "/"+postSlug)
+	if err != nil {
+		return fmt.Errorf("failed to query monologues: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan monologue: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := db.syncBlogPostLinks(tx, id, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncBlogPostLinks replaces the attached links of a monologue with postIDs unless postIDs
+// is nil, then detects the links in its current content again
+func (db *DB) syncBlogPostLinks(tx *sql.Tx, monologueID string, postIDs []string) error {
+	if postIDs != nil {
+		if _, err := tx.Exec("DELETE FROM monologue_blog_posts WHERE monologue_id = $1", monologueID); err != nil {
+			return fmt.Errorf("failed to clear blog post links: %w", err)
+		}
+		for i, postID := range postIDs {
+			_, err := tx.Exec(`
+				INSERT INTO monologue_blog_posts (monologue_id, blog_post_id, position)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (monologue_id, blog_post_id) DO NOTHING
+			`, monologueID, postID, i)
+			if err != nil {
+				return fmt.Errorf("failed to link blog post: %w", err)
+			}
+		}
+	} else {
+		if _, err := tx.Exec("DELETE FROM monologue_blog_posts WHERE monologue_id = $1 AND detected", monologueID); err != nil {
+			return fmt.Errorf("failed to clear detected blog post links: %w", err)
+		}
+	}
+
+	if db.PostLinks == nil {
+		return nil
+	}
+
+	var content string
+	if err := tx.QueryRow("SELECT content FROM monologues WHERE id = $1", monologueID).Scan(&content); err != nil {
+		return fmt.Errorf("failed to read monologue content: %w", err)
+	}
+	slugs := db.PostLinks.Slugs(content)
+	if len(slugs) == 0 {
+		return nil
+	}
+
+	// Links by a previous slug still reach the post
+	_, err := tx.Exec(`
+		INSERT INTO monologue_blog_posts (monologue_id, blog_post_id, position, detected)
+		SELECT $1, p.id, MIN(s.ord)::int, true
+		FROM unnest($2::text[]) WITH ORDINALITY AS s(slug, ord)
+		JOIN blog_posts p ON p.slug = s.slug
+			OR p.id = (SELECT blog_post_id FROM blog_post_slug_history h WHERE h.slug = s.slug)
+		GROUP BY p.id
+		ON CONFLICT (monologue_id, blog_post_id) DO NOTHING
+	`, monologueID, pq.Array(slugs))
+	if err != nil {
+		return fmt.Errorf("failed to link detected blog posts: %w", err)
+	}
+	return nil
+}
@@ -1,11 +1,18 @@
 package database
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/naoya0117/portfolio-v2025-api/internal/locale"
 	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/slug"
+	"github.com/naoya0117/portfolio-v2025-api/internal/textutil"
+	"github.com/naoya0117/portfolio-v2025-api/internal/urlpreview"
 )
 
 // Blog Post mutations
@@ -24,14 +31,33 @@ func (db *DB) CreateBlogPost(input models.CreateBlogPostInput) (*models.BlogPost
 
 	query := `
 		INSERT INTO blog_posts (title, slug, excerpt, content, cover_image_url, tags,
-							   status, seo_title, seo_description, published_at, like_count)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+							   status, seo_title, seo_description, published_at, like_count,
+							   reading_time, character_count, locale, tag_keys)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id, created_at, updated_at
 	`
 
+	postSlug := ""
+	if input.Slug != nil {
+		postSlug = *input.Slug
+	} else {
+		generated, err := db.UniqueBlogPostSlug(slug.Generate(input.Title), "")
+		if err != nil {
+			return nil, err
+		}
+		postSlug = generated
+	}
+
+	postLocale := locale.Default
+	if input.Locale != nil {
+		postLocale = *input.Locale
+	}
+
+	stats := textutil.Analyze(input.Content)
+
 	post := &models.BlogPost{
 		Title:          input.Title,
-		Slug:           input.Slug,
+		Slug:           postSlug,
 		Excerpt:        input.Excerpt,
 		Content:        input.Content,
 		CoverImageURL:  input.CoverImageURL,
@@ -41,19 +67,39 @@ func (db *DB) CreateBlogPost(input models.CreateBlogPostInput) (*models.BlogPost
 		SeoDescription: input.SeoDescription,
 		PublishedAt:    publishedAt,
 		LikeCount:      intPtr(0),
+		ReadingTime:    stats.ReadingMinutes(),
+		CharacterCount: stats.Characters,
+		Locale:         postLocale,
+		OriginalLocale: postLocale,
 	}
 
-	err := db.QueryRow(
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(
 		query, post.Title, post.Slug, ptrToNullString(post.Excerpt),
 		post.Content, ptrToNullString(post.CoverImageURL), pq.Array(post.Tags),
 		post.Status, ptrToNullString(post.SeoTitle), ptrToNullString(post.SeoDescription),
 		ptrToNullString(post.PublishedAt), post.LikeCount,
+		post.ReadingTime, post.CharacterCount, post.OriginalLocale, pq.Array(tagKeys(post.Tags)),
 	).Scan(&post.ID, &post.CreatedAt, &post.UpdatedAt)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create blog post: %w", err)
 	}
 
+	// Monologues may have linked to the post before it existed
+	if err := db.linkMonologuesTo(tx, post.Slug); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return post, nil
 }
 
@@ -82,6 +128,11 @@ func (db *DB) UpdateBlogPost(id string, input models.UpdateBlogPostInput) (*mode
 		setParts = append(setParts, fmt.Sprintf("content = $%d", argIndex))
 		args = append(args, *input.Content)
 		argIndex++
+
+		stats := textutil.Analyze(*input.Content)
+		setParts = append(setParts, fmt.Sprintf("reading_time = $%d, character_count = $%d", argIndex, argIndex+1))
+		args = append(args, stats.ReadingMinutes(), stats.Characters)
+		argIndex += 2
 	}
 	if input.CoverImageURL != nil {
 		setParts = append(setParts, fmt.Sprintf("cover_image_url = $%d", argIndex))
@@ -89,9 +140,9 @@ func (db *DB) UpdateBlogPost(id string, input models.UpdateBlogPostInput) (*mode
 		argIndex++
 	}
 	if input.Tags != nil {
-		setParts = append(setParts, fmt.Sprintf("tags = $%d", argIndex))
-		args = append(args, pq.Array(input.Tags))
-		argIndex++
+		setParts = append(setParts, fmt.Sprintf("tags = $%d, tag_keys = $%d", argIndex, argIndex+1))
+		args = append(args, pq.Array(input.Tags), pq.Array(tagKeys(input.Tags)))
+		argIndex += 2
 	}
 	if input.Status != nil {
 		setParts = append(setParts, fmt.Sprintf("status = $%d", argIndex))
@@ -104,6 +155,10 @@ func (db *DB) UpdateBlogPost(id string, input models.UpdateBlogPostInput) (*mode
 			args = append(args, time.Now().Format(time.RFC3339))
 			argIndex++
 		}
+		// Only drafts can be waiting to be published
+		if *input.Status != models.BlogStatusDraft {
+			setParts = append(setParts, "scheduled_at = NULL")
+		}
 	}
 	if input.SeoTitle != nil {
 		setParts = append(setParts, fmt.Sprintf("seo_title = $%d", argIndex))
@@ -115,19 +170,64 @@ func (db *DB) UpdateBlogPost(id string, input models.UpdateBlogPostInput) (*mode
 		args = append(args, ptrToNullString(input.SeoDescription))
 		argIndex++
 	}
+	if input.Locale != nil {
+		setParts = append(setParts, fmt.Sprintf("locale = $%d", argIndex))
+		args = append(args, *input.Locale)
+		argIndex++
+	}
 
 	// Add WHERE clause
 	query := fmt.Sprintf("UPDATE blog_posts SET %s WHERE id = $%d", 
 		joinStrings(setParts, ", "), argIndex)
 	args = append(args, id)
 
-	_, err := db.Exec(query, args...)
+	tx, err := db.Begin()
 	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if input.Slug != nil {
+		// Keep the previous slug so old links can be redirected to the new one
+		_, err = tx.Exec(`
+			INSERT INTO blog_post_slug_history (slug, blog_post_id)
+			SELECT slug, id FROM blog_posts WHERE id = $1 AND slug <> $2
+			ON CONFLICT (slug) DO UPDATE SET blog_post_id = EXCLUDED.blog_post_id, created_at = NOW()
+		`, id, *input.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record slug history: %w", err)
+		}
+
+		_, err = tx.Exec("DELETE FROM blog_post_slug_history WHERE slug = $1", *input.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record slug history: %w", err)
+		}
+	}
+
+	if input.Locale != nil {
+		if err := checkOriginalLocale(tx, id, *input.Locale); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
 		return nil, fmt.Errorf("failed to update blog post: %w", err)
 	}
 
+	// Links by the previous slug keep working through the slug history; links by the new
+	// one are detected here
+	if input.Slug != nil {
+		if err := db.linkMonologuesTo(tx, *input.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	// Return updated post
-	posts, err := db.queryBlogPosts("SELECT id, title, slug, excerpt, content, cover_image_url, tags, status, seo_title, seo_description, published_at, like_count, created_at, updated_at FROM blog_posts WHERE id = $1", id)
+	posts, err := db.queryBlogPosts("SELECT " + blogPostColumns + " FROM blog_posts WHERE id = $1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +258,7 @@ func (db *DB) PublishBlogPost(id string) (*models.BlogPost, error) {
 		UPDATE blog_posts 
 		SET status = 'PUBLISHED', 
 			published_at = COALESCE(published_at, $1),
+			scheduled_at = NULL,
 			updated_at = NOW()
 		WHERE id = $2
 	`
@@ -167,7 +268,7 @@ func (db *DB) PublishBlogPost(id string) (*models.BlogPost, error) {
 		return nil, fmt.Errorf("failed to publish blog post: %w", err)
 	}
 
-	posts, err := db.queryBlogPosts("SELECT id, title, slug, excerpt, content, cover_image_url, tags, status, seo_title, seo_description, published_at, like_count, created_at, updated_at FROM blog_posts WHERE id = $1", id)
+	posts, err := db.queryBlogPosts("SELECT " + blogPostColumns + " FROM blog_posts WHERE id = $1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +291,7 @@ func (db *DB) UnpublishBlogPost(id string) (*models.BlogPost, error) {
 		return nil, fmt.Errorf("failed to unpublish blog post: %w", err)
 	}
 
-	posts, err := db.queryBlogPosts("SELECT id, title, slug, excerpt, content, cover_image_url, tags, status, seo_title, seo_description, published_at, like_count, created_at, updated_at FROM blog_posts WHERE id = $1", id)
+	posts, err := db.queryBlogPosts("SELECT " + blogPostColumns + " FROM blog_posts WHERE id = $1", id)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +303,9 @@ func (db *DB) UnpublishBlogPost(id string) (*models.BlogPost, error) {
 }
 
 // Monologue mutations
-func (db *DB) CreateMonologue(input models.CreateMonologueInput) (*models.Monologue, error) {
+// CreateMonologue stores a monologue together with its images. Creating an IMAGE
+// monologue as published fails unless the images satisfy ensurePublishable.
+func (db *DB) CreateMonologue(input models.CreateMonologueInput, images []*models.MonologueImage) (*models.Monologue, error) {
 	now := time.Now()
 	isPublished := false
 	if input.IsPublished != nil {
@@ -217,59 +320,98 @@ func (db *DB) CreateMonologue(input models.CreateMonologueInput) (*models.Monolo
 
 	query := `
 		INSERT INTO monologues (content, content_type, code_language, code_snippet, tags,
-							   is_published, published_at, url, series, category)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+							   is_published, published_at, url, category,
+							   code_category_id, difficulty, parent_id, reading_time, character_count,
+							   tag_keys)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id, created_at, updated_at
 	`
 
+	stats := textutil.Analyze(input.Content)
+
 	mono := &models.Monologue{
-		Content:      input.Content,
-		ContentType:  input.ContentType,
-		CodeLanguage: input.CodeLanguage,
-		CodeSnippet:  input.CodeSnippet,
-		Tags:         input.Tags,
-		IsPublished:  isPublished,
-		PublishedAt:  publishedAt,
-		URL:          input.URL,
-		Series:       input.Series,
-		Category:     input.Category,
-		LikeCount:    intPtr(0),
+		Content:        input.Content,
+		ContentType:    input.ContentType,
+		CodeLanguage:   input.CodeLanguage,
+		CodeSnippet:    input.CodeSnippet,
+		Tags:           input.Tags,
+		IsPublished:    isPublished,
+		PublishedAt:    publishedAt,
+		URL:            input.URL,
+		Category:       input.Category,
+		CodeCategoryID: input.CodeCategoryID,
+		Difficulty:     input.Difficulty,
+		ParentID:       input.ParentID,
+		LikeCount:      intPtr(0),
+		ReadingTime:    stats.ReadingMinutes(),
+		CharacterCount: stats.Characters,
 	}
 
 
-	err := db.QueryRow(
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(
 		query, mono.Content, mono.ContentType, ptrToNullString(mono.CodeLanguage),
 		ptrToNullString(mono.CodeSnippet), pq.Array(mono.Tags), mono.IsPublished,
 		ptrToNullString(mono.PublishedAt), ptrToNullString(mono.URL),
-		ptrToNullString(mono.Series), ptrToNullString(mono.Category),
+		ptrToNullString(mono.Category), ptrToNullString(mono.CodeCategoryID),
+		difficultyValue(mono.Difficulty), ptrToNullString(mono.ParentID),
+		mono.ReadingTime, mono.CharacterCount, pq.Array(tagKeys(mono.Tags)),
 	).Scan(&mono.ID, &mono.CreatedAt, &mono.UpdatedAt)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create monologue: %w", err)
 	}
 
-	// Generate URL preview if URL is provided
-	if mono.URL != nil {
-		preview, err := db.CreateURLPreview(mono.ID, *mono.URL)
-		if err == nil {
-			mono.URLPreview = preview
+	if input.Series != nil && strings.TrimSpace(*input.Series) != "" {
+		title := strings.TrimSpace(*input.Series)
+		if err := joinSeriesByTitle(tx, mono.ID, title); err != nil {
+			return nil, err
 		}
+		mono.Series = &title
+	}
+
+	if err := replaceMonologueImages(tx, mono.ID, images); err != nil {
+		return nil, err
+	}
+	mono.Images = images
+	if mono.Images == nil {
+		mono.Images = []*models.MonologueImage{}
+	}
+	if err := db.syncBlogPostLinks(tx, mono.ID, input.RelatedBlogPostIDs); err != nil {
+		return nil, err
+	}
+	if err := ensurePublishable(tx, mono.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit monologue: %w", err)
 	}
 
 	return mono, nil
 }
 
-func (db *DB) UpdateMonologue(id string, input models.UpdateMonologueInput) (*models.Monologue, error) {
+// UpdateMonologue applies the set fields of input. images replaces the monologue's images
+// unless it is nil.
+func (db *DB) UpdateMonologue(id string, input models.UpdateMonologueInput, images []*models.MonologueImage) (*models.Monologue, error) {
 	setParts := []string{"updated_at = NOW()"}
 	args := []interface{}{}
 	argIndex := 1
 
-	urlChanged := false
-
 	if input.Content != nil {
 		setParts = append(setParts, fmt.Sprintf("content = $%d", argIndex))
 		args = append(args, *input.Content)
 		argIndex++
+
+		stats := textutil.Analyze(*input.Content)
+		setParts = append(setParts, fmt.Sprintf("reading_time = $%d, character_count = $%d", argIndex, argIndex+1))
+		args = append(args, stats.ReadingMinutes(), stats.Characters)
+		argIndex += 2
 	}
 	if input.ContentType != nil {
 		setParts = append(setParts, fmt.Sprintf("content_type = $%d", argIndex))
@@ -287,9 +429,9 @@ func (db *DB) UpdateMonologue(id string, input models.UpdateMonologueInput) (*mo
 		argIndex++
 	}
 	if input.Tags != nil {
-		setParts = append(setParts, fmt.Sprintf("tags = $%d", argIndex))
-		args = append(args, pq.Array(input.Tags))
-		argIndex++
+		setParts = append(setParts, fmt.Sprintf("tags = $%d, tag_keys = $%d", argIndex, argIndex+1))
+		args = append(args, pq.Array(input.Tags), pq.Array(tagKeys(input.Tags)))
+		argIndex += 2
 	}
 	if input.IsPublished != nil {
 		setParts = append(setParts, fmt.Sprintf("is_published = $%d", argIndex))
@@ -300,54 +442,224 @@ func (db *DB) UpdateMonologue(id string, input models.UpdateMonologueInput) (*mo
 			setParts = append(setParts, fmt.Sprintf("published_at = COALESCE(published_at, $%d)", argIndex))
 			args = append(args, time.Now().Format(time.RFC3339))
 			argIndex++
+			setParts = append(setParts, "scheduled_at = NULL")
 		}
 	}
 	if input.URL != nil {
 		setParts = append(setParts, fmt.Sprintf("url = $%d", argIndex))
 		args = append(args, ptrToNullString(input.URL))
 		argIndex++
-		urlChanged = true
-	}
-	if input.Series != nil {
-		setParts = append(setParts, fmt.Sprintf("series = $%d", argIndex))
-		args = append(args, ptrToNullString(input.Series))
-		argIndex++
 	}
 	if input.Category != nil {
 		setParts = append(setParts, fmt.Sprintf("category = $%d", argIndex))
 		args = append(args, ptrToNullString(input.Category))
 		argIndex++
 	}
+	if input.CodeCategoryID != nil {
+		setParts = append(setParts, fmt.Sprintf("code_category_id = NULLIF($%d, '')::uuid", argIndex))
+		args = append(args, *input.CodeCategoryID)
+		argIndex++
+	}
+	if input.Difficulty != nil {
+		setParts = append(setParts, fmt.Sprintf("difficulty = $%d", argIndex))
+		args = append(args, string(*input.Difficulty))
+		argIndex++
+	} else if input.ClearDifficulty != nil && *input.ClearDifficulty {
+		setParts = append(setParts, "difficulty = NULL")
+	}
 
 	query := fmt.Sprintf("UPDATE monologues SET %s WHERE id = $%d", 
 		joinStrings(setParts, ", "), argIndex)
 	args = append(args, id)
 
-	_, err := db.Exec(query, args...)
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update monologue: %w", err)
 	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, fmt.Errorf("monologue not found")
+	}
 
-	// Handle URL preview regeneration
-	if urlChanged && input.URL != nil {
-		// Delete existing preview
-		db.DeleteURLPreviewByMonologueID(id)
-		
-		if *input.URL != "" {
-			// Create new preview
-			db.CreateURLPreview(id, *input.URL)
+	if input.Series != nil {
+		if title := strings.TrimSpace(*input.Series); title != "" {
+			err = joinSeriesByTitle(tx, id, title)
+		} else {
+			err = leaveSeries(tx, id)
+		}
+		if err != nil {
+			return nil, err
 		}
 	}
+	if images != nil {
+		if err := replaceMonologueImages(tx, id, images); err != nil {
+			return nil, err
+		}
+	}
+	if input.Content != nil || input.RelatedBlogPostIDs != nil {
+		if err := db.syncBlogPostLinks(tx, id, input.RelatedBlogPostIDs); err != nil {
+			return nil, err
+		}
+	}
+	if input.IsPublished != nil && !*input.IsPublished {
+		if err := unpublishReplies(tx, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := ensurePublishable(tx, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit monologue: %w", err)
+	}
 
 	return db.GetMonologueByID(id)
 }
 
+// ErrNotArchivable is returned when archiving a blog post that was never published.
+// Archived posts stay reachable by slug, so archiving a draft would expose it.
+var ErrNotArchivable = errors.New("only published blog posts can be archived")
+
+// ArchiveBlogPost removes a published post from lists while keeping it reachable by slug.
+// Archiving an archived post changes nothing. Returns nil when the post does not exist.
+func (db *DB) ArchiveBlogPost(id string) (*models.BlogPost, error) {
+	query := `
+		UPDATE blog_posts
+		SET status = 'ARCHIVED', scheduled_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'PUBLISHED'
+	`
+
+	if _, err := db.Exec(query, id); err != nil {
+		return nil, fmt.Errorf("failed to archive blog post: %w", err)
+	}
+
+	post, err := db.GetBlogPostByID(id)
+	if err != nil || post == nil {
+		return nil, err
+	}
+	if post.Status != models.BlogStatusArchived {
+		return nil, ErrNotArchivable
+	}
+
+	return post, nil
+}
+
+// UnarchiveBlogPost publishes an archived post again, keeping its original publish date.
+// Posts that are not archived are returned unchanged.
+func (db *DB) UnarchiveBlogPost(id string) (*models.BlogPost, error) {
+	query := `
+		UPDATE blog_posts
+		SET status = 'PUBLISHED', updated_at = NOW()
+		WHERE id = $1 AND status = 'ARCHIVED'
+	`
+
+	if _, err := db.Exec(query, id); err != nil {
+		return nil, fmt.Errorf("failed to unarchive blog post: %w", err)
+	}
+
+	return db.GetBlogPostByID(id)
+}
+
+// Reasons an IMAGE monologue cannot be published
+var (
+	ErrImagesRequired   = errors.New("IMAGE monologues need at least one image to be published")
+	ErrImageAltRequired = errors.New("every image of an IMAGE monologue needs alt text to be published")
+)
+
+// replaceMonologueImages stores images as the monologue's images, in order
+func replaceMonologueImages(tx *sql.Tx, monologueID string, images []*models.MonologueImage) error {
+	if _, err := tx.Exec("DELETE FROM monologue_images WHERE monologue_id = $1", monologueID); err != nil {
+		return fmt.Errorf("failed to clear monologue images: %w", err)
+	}
+
+	query := `
+		INSERT INTO monologue_images (monologue_id, position, media_id, url, alt_text, width, height,
+		                              blurhash, dominant_color, caption)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	for i, image := range images {
+		_, err := tx.Exec(query, monologueID, i,
+			ptrToNullString(image.MediaID), image.URL, ptrToNullString(image.Alt),
+			ptrToNullInt(image.Width), ptrToNullInt(image.Height),
+			ptrToNullString(image.Blurhash), ptrToNullString(image.DominantColor),
+			ptrToNullString(image.Caption),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create monologue image: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ensurePublishable fails when the monologue is published as an IMAGE monologue without
+// images or with an image that has no alt text, or as a reply to an unpublished monologue.
+// Called after every write that can leave a monologue in that state, so the rules hold no
+// matter how it was published.
+func ensurePublishable(tx *sql.Tx, monologueID string) error {
+	if err := checkMonologueImages(tx, monologueID, true); err != nil {
+		return err
+	}
+	return checkParentPublished(tx, monologueID)
+}
+
+// checkMonologueImages applies the image rules of ensurePublishable. Unless onlyPublished
+// is set, they are applied to unpublished monologues too, e.g. ones about to be scheduled.
+func checkMonologueImages(tx *sql.Tx, monologueID string, onlyPublished bool) error {
+	query := `
+		SELECT m.is_published, m.content_type, COUNT(i.position),
+		       COUNT(i.position) FILTER (WHERE COALESCE(btrim(i.alt_text), '') = '')
+		FROM monologues m
+		LEFT JOIN monologue_images i ON i.monologue_id = m.id
+		WHERE m.id = $1
+		GROUP BY m.id
+	`
+
+	var published bool
+	var contentType models.ContentType
+	var images, missingAlt int
+	err := tx.QueryRow(query, monologueID).Scan(&published, &contentType, &images, &missingAlt)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check monologue images: %w", err)
+	}
+
+	if (onlyPublished && !published) || contentType != models.ContentTypeImage {
+		return nil
+	}
+	if images == 0 {
+		return ErrImagesRequired
+	}
+	if missingAlt > 0 {
+		return ErrImageAltRequired
+	}
+	return nil
+}
+
+// DeleteMonologue removes a monologue. Its replies are attached to its parent.
 func (db *DB) DeleteMonologue(id string) (bool, error) {
-	// Delete related URL previews first
-	db.DeleteURLPreviewByMonologueID(id)
-	
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := detachReplies(tx, id); err != nil {
+		return false, err
+	}
+
 	query := "DELETE FROM monologues WHERE id = $1"
-	result, err := db.Exec(query, id)
+	result, err := tx.Exec(query, id)
 	if err != nil {
 		return false, fmt.Errorf("failed to delete monologue: %w", err)
 	}
@@ -357,6 +669,10 @@ func (db *DB) DeleteMonologue(id string) (bool, error) {
 		return false, err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit monologue: %w", err)
+	}
+
 	return rowsAffected > 0, nil
 }
 
@@ -365,18 +681,34 @@ func (db *DB) PublishMonologue(id string) (*models.Monologue, error) {
 		UPDATE monologues 
 		SET is_published = true, 
 			published_at = $1,
+			scheduled_at = NULL,
 			updated_at = NOW()
 		WHERE id = $2
 	`
 
-	_, err := db.Exec(query, time.Now().Format(time.RFC3339), id)
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(query, time.Now().Format(time.RFC3339), id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to publish monologue: %w", err)
 	}
 
+	if err := ensurePublishable(tx, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit monologue: %w", err)
+	}
+
 	return db.GetMonologueByID(id)
 }
 
+// UnpublishMonologue unpublishes a monologue together with its replies
 func (db *DB) UnpublishMonologue(id string) (*models.Monologue, error) {
 	query := `
 		UPDATE monologues 
@@ -386,11 +718,25 @@ func (db *DB) UnpublishMonologue(id string) (*models.Monologue, error) {
 		WHERE id = $1
 	`
 
-	_, err := db.Exec(query, id)
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(query, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unpublish monologue: %w", err)
 	}
 
+	if err := unpublishReplies(tx, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit monologue: %w", err)
+	}
+
 	return db.GetMonologueByID(id)
 }
 
@@ -418,12 +764,6 @@ func (db *DB) LikeMonologue(id string) (*models.LikeResponse, error) {
 }
 
 func (db *DB) LikeBlogPost(id string) (*models.LikeResponse, error) {
-	// Handle the case where the ID might have "blog-" prefix
-	cleanID := id
-	if len(id) > 5 && id[:5] == "blog-" {
-		cleanID = id[5:]
-	}
-	
 	// Increment like count for blog post
 	query := `
 		UPDATE blog_posts 
@@ -434,13 +774,13 @@ func (db *DB) LikeBlogPost(id string) (*models.LikeResponse, error) {
 	`
 
 	var likeCount int
-	err := db.QueryRow(query, cleanID).Scan(&likeCount)
+	err := db.QueryRow(query, id).Scan(&likeCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to like blog post: %w", err)
 	}
 
 	return &models.LikeResponse{
-		ID:        cleanID,
+		ID:        id,
 		LikeCount: likeCount,
 		IsLiked:   true,
 	}, nil
@@ -448,41 +788,511 @@ func (db *DB) LikeBlogPost(id string) (*models.LikeResponse, error) {
 
 
 // URL Preview methods
-func (db *DB) CreateURLPreview(monologueID, url string) (*models.URLPreview, error) {
-	// In a real implementation, this would fetch the URL and extract metadata
-	// For now, create a mock preview
-	preview := &models.URLPreview{
-		Title:       "Generated Preview",
-		Description: stringPtr("This is a generated preview for " + url),
-		ImageURL:    stringPtr("https://via.placeholder.com/400x200"),
-		SiteName:    stringPtr("Example Site"),
-		URL:         url,
-		Favicon:     stringPtr("https://via.placeholder.com/32x32"),
-		CreatedAt:   time.Now(),
+
+// SaveURLPreviewEntry stores the result of fetching a normalized URL. A successful fetch
+// replaces the previous entry. A failed one is stored with only the error and the
+// requested URL, unless a preview is stored already: that preview is kept and only the
+// time of the failure is recorded.
+func (db *DB) SaveURLPreviewEntry(key string, entry *urlpreview.Entry) error {
+	return saveURLPreviewEntry(db, key, entry)
+}
+
+func saveURLPreviewEntry(exec interface {
+	Exec(string, ...interface{}) (sql.Result, error)
+}, key string, entry *urlpreview.Entry) error {
+	var err error
+	if entry.Preview == nil {
+		_, err = exec.Exec(`
+			INSERT INTO link_previews (normalized_url, url, error, fetched_at, expires_at, failed_at)
+			VALUES ($1, $1, $2, $3, $4, $5)
+			ON CONFLICT (normalized_url) DO UPDATE SET
+				failed_at = EXCLUDED.failed_at,
+				error = CASE WHEN link_previews.error IS NULL THEN NULL ELSE EXCLUDED.error END,
+				fetched_at = CASE WHEN link_previews.error IS NULL THEN link_previews.fetched_at ELSE EXCLUDED.fetched_at END,
+				expires_at = CASE WHEN link_previews.error IS NULL THEN link_previews.expires_at ELSE EXCLUDED.expires_at END
+		`, key, entry.Err, entry.FetchedAt, entry.ExpiresAt, entry.FailedAt)
+	} else {
+		preview := entry.Preview
+		_, err = exec.Exec(`
+			INSERT INTO link_previews (normalized_url, url, title, description, image_url, site_name,
+									   favicon, error, fetched_at, expires_at, failed_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NULL, $8, $9, NULL)
+			ON CONFLICT (normalized_url) DO UPDATE SET
+				url = EXCLUDED.url, title = EXCLUDED.title, description = EXCLUDED.description,
+				image_url = EXCLUDED.image_url, site_name = EXCLUDED.site_name, favicon = EXCLUDED.favicon,
+				error = NULL, fetched_at = EXCLUDED.fetched_at, expires_at = EXCLUDED.expires_at,
+				failed_at = NULL
+		`, key, preview.URL, preview.Title, ptrToNullString(preview.Description),
+			ptrToNullString(preview.ImageURL), ptrToNullString(preview.SiteName),
+			ptrToNullString(preview.Favicon), entry.FetchedAt, entry.ExpiresAt,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to save URL preview: %w", err)
+	}
+
+	return nil
+}
+
+// Profile mutations
+func (db *DB) CreateProfile(input models.CreateProfileInput) (*models.Profile, error) {
+	query := `
+		INSERT INTO profiles (name, title, bio, avatar_url)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id string
+	err := db.QueryRow(
+		query, input.Name, ptrToNullString(input.Title),
+		ptrToNullString(input.Bio), ptrToNullString(input.AvatarURL),
+	).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	return db.GetProfile(id)
+}
+
+func (db *DB) UpdateProfile(id string, input models.UpdateProfileInput) (*models.Profile, error) {
+	setParts := []string{"updated_at = NOW()"}
+	args := []interface{}{}
+	argIndex := 1
+
+	if input.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, *input.Name)
+		argIndex++
+	}
+	if input.Title != nil {
+		setParts = append(setParts, fmt.Sprintf("title = $%d", argIndex))
+		args = append(args, ptrToNullString(input.Title))
+		argIndex++
+	}
+	if input.Bio != nil {
+		setParts = append(setParts, fmt.Sprintf("bio = $%d", argIndex))
+		args = append(args, ptrToNullString(input.Bio))
+		argIndex++
+	}
+	if input.AvatarURL != nil {
+		setParts = append(setParts, fmt.Sprintf("avatar_url = $%d", argIndex))
+		args = append(args, ptrToNullString(input.AvatarURL))
+		argIndex++
+	}
+
+	query := fmt.Sprintf("UPDATE profiles SET %s WHERE id = $%d",
+		joinStrings(setParts, ", "), argIndex)
+	args = append(args, id)
+
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err == nil && rowsAffected == 0 {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	return db.GetProfile(id)
+}
+
+func (db *DB) DeleteProfile(id string) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Social links reference the profile without ON DELETE CASCADE
+	if _, err := tx.Exec("DELETE FROM social_links WHERE profile_id = $1", id); err != nil {
+		return false, fmt.Errorf("failed to delete social links: %w", err)
+	}
+
+	result, err := tx.Exec("DELETE FROM profiles WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete profile: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Social link mutations
+func (db *DB) CreateSocialLink(profileID string, input models.CreateSocialLinkInput) (*models.SocialLink, error) {
+	query := `
+		INSERT INTO social_links (profile_id, platform, url, icon)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	link := &models.SocialLink{
+		Platform: input.Platform,
+		URL:      input.URL,
+		Icon:     input.Icon,
+	}
+
+	err := db.QueryRow(query, profileID, link.Platform, link.URL, ptrToNullString(link.Icon)).Scan(&link.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create social link: %w", err)
+	}
+
+	return link, nil
+}
+
+func (db *DB) UpdateSocialLink(id string, input models.UpdateSocialLinkInput) (*models.SocialLink, error) {
+	setParts := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	if input.Platform != nil {
+		setParts = append(setParts, fmt.Sprintf("platform = $%d", argIndex))
+		args = append(args, *input.Platform)
+		argIndex++
+	}
+	if input.URL != nil {
+		setParts = append(setParts, fmt.Sprintf("url = $%d", argIndex))
+		args = append(args, *input.URL)
+		argIndex++
+	}
+	if input.Icon != nil {
+		setParts = append(setParts, fmt.Sprintf("icon = $%d", argIndex))
+		args = append(args, ptrToNullString(input.Icon))
+		argIndex++
+	}
+
+	if len(setParts) > 0 {
+		query := fmt.Sprintf("UPDATE social_links SET %s WHERE id = $%d",
+			joinStrings(setParts, ", "), argIndex)
+		args = append(args, id)
+
+		if _, err := db.Exec(query, args...); err != nil {
+			return nil, fmt.Errorf("failed to update social link: %w", err)
+		}
+	}
+
+	link, err := db.GetSocialLinkByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, fmt.Errorf("social link not found")
+	}
+
+	return link, nil
+}
+
+func (db *DB) DeleteSocialLink(id string) (bool, error) {
+	result, err := db.Exec("DELETE FROM social_links WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete social link: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Skill mutations
+func (db *DB) CreateSkill(input models.CreateSkillInput) (*models.Skill, error) {
+	// New skills go to the end unless an explicit position is given
+	query := `
+		INSERT INTO skills (name, category, level, icon_url, display_order)
+		VALUES ($1, $2, $3, $4, COALESCE($5, (SELECT COALESCE(MAX(display_order), 0) + 1 FROM skills)))
+		RETURNING id
+	`
+
+	var id string
+	err := db.QueryRow(
+		query, input.Name, input.Category, input.Level,
+		ptrToNullString(input.IconURL), ptrToNullInt(input.DisplayOrder),
+	).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create skill: %w", err)
+	}
+
+	return db.GetSkillByID(id)
+}
+
+func (db *DB) UpdateSkill(id string, input models.UpdateSkillInput) (*models.Skill, error) {
+	setParts := []string{"updated_at = NOW()"}
+	args := []interface{}{}
+	argIndex := 1
+
+	if input.Name != nil {
+		setParts = append(setParts, fmt.Sprintf("name = $%d", argIndex))
+		args = append(args, *input.Name)
+		argIndex++
+	}
+	if input.Category != nil {
+		setParts = append(setParts, fmt.Sprintf("category = $%d", argIndex))
+		args = append(args, *input.Category)
+		argIndex++
+	}
+	if input.Level != nil {
+		setParts = append(setParts, fmt.Sprintf("level = $%d", argIndex))
+		args = append(args, *input.Level)
+		argIndex++
+	}
+	if input.IconURL != nil {
+		setParts = append(setParts, fmt.Sprintf("icon_url = $%d", argIndex))
+		args = append(args, ptrToNullString(input.IconURL))
+		argIndex++
+	}
+	if input.DisplayOrder != nil {
+		setParts = append(setParts, fmt.Sprintf("display_order = $%d", argIndex))
+		args = append(args, *input.DisplayOrder)
+		argIndex++
+	}
+
+	query := fmt.Sprintf("UPDATE skills SET %s WHERE id = $%d",
+		joinStrings(setParts, ", "), argIndex)
+	args = append(args, id)
+
+	if _, err := db.Exec(query, args...); err != nil {
+		return nil, fmt.Errorf("failed to update skill: %w", err)
+	}
+
+	skill, err := db.GetSkillByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if skill == nil {
+		return nil, fmt.Errorf("skill not found")
+	}
+
+	return skill, nil
+}
+
+func (db *DB) DeleteSkill(id string) (bool, error) {
+	result, err := db.Exec("DELETE FROM skills WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete skill: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ReorderSkills sets each skill's display_order to its position in ids (starting at 1)
+func (db *DB) ReorderSkills(ids []string) ([]*models.Skill, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, id := range ids {
+		result, err := tx.Exec(
+			"UPDATE skills SET display_order = $1, updated_at = NOW() WHERE id = $2",
+			i+1, id,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reorder skills: %w", err)
+		}
+		if rowsAffected, err := result.RowsAffected(); err == nil && rowsAffected == 0 {
+			return nil, fmt.Errorf("skill %s not found", id)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return db.GetSkills()
+}
+
+// Experience mutations
+func (db *DB) CreateExperience(input models.CreateExperienceInput) (*models.Experience, error) {
+	isCurrent := false
+	if input.IsCurrent != nil {
+		isCurrent = *input.IsCurrent
 	}
 
 	query := `
-		INSERT INTO url_previews (monologue_id, title, description, image_url, site_name, url, favicon)
+		INSERT INTO experiences (company, position, description, start_date, end_date, is_current, technologies)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
 	`
 
-	_, err := db.Exec(
-		query, monologueID, preview.Title, ptrToNullString(preview.Description),
-		ptrToNullString(preview.ImageURL), ptrToNullString(preview.SiteName),
-		preview.URL, ptrToNullString(preview.Favicon),
-	)
+	var id string
+	err := db.QueryRow(
+		query, input.Company, input.Position, ptrToNullString(input.Description),
+		input.StartDate, ptrToNullString(input.EndDate), isCurrent, pq.Array(input.Technologies),
+	).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create experience: %w", err)
+	}
 
+	return db.GetExperienceByID(id)
+}
+
+func (db *DB) UpdateExperience(id string, input models.UpdateExperienceInput) (*models.Experience, error) {
+	setParts := []string{"updated_at = NOW()"}
+	args := []interface{}{}
+	argIndex := 1
+
+	if input.Company != nil {
+		setParts = append(setParts, fmt.Sprintf("company = $%d", argIndex))
+		args = append(args, *input.Company)
+		argIndex++
+	}
+	if input.Position != nil {
+		setParts = append(setParts, fmt.Sprintf("position = $%d", argIndex))
+		args = append(args, *input.Position)
+		argIndex++
+	}
+	if input.Description != nil {
+		setParts = append(setParts, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, ptrToNullString(input.Description))
+		argIndex++
+	}
+	if input.StartDate != nil {
+		setParts = append(setParts, fmt.Sprintf("start_date = $%d", argIndex))
+		args = append(args, *input.StartDate)
+		argIndex++
+	}
+	if input.EndDate != nil {
+		setParts = append(setParts, fmt.Sprintf("end_date = $%d", argIndex))
+		args = append(args, ptrToNullString(input.EndDate))
+		argIndex++
+	}
+	if input.IsCurrent != nil {
+		setParts = append(setParts, fmt.Sprintf("is_current = $%d", argIndex))
+		args = append(args, *input.IsCurrent)
+		argIndex++
+	}
+	if input.Technologies != nil {
+		setParts = append(setParts, fmt.Sprintf("technologies = $%d", argIndex))
+		args = append(args, pq.Array(input.Technologies))
+		argIndex++
+	}
+
+	query := fmt.Sprintf("UPDATE experiences SET %s WHERE id = $%d",
+		joinStrings(setParts, ", "), argIndex)
+	args = append(args, id)
+
+	if _, err := db.Exec(query, args...); err != nil {
+		return nil, fmt.Errorf("failed to update experience: %w", err)
+	}
+
+	exp, err := db.GetExperienceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if exp == nil {
+		return nil, fmt.Errorf("experience not found")
+	}
+
+	return exp, nil
+}
+
+func (db *DB) DeleteExperience(id string) (bool, error) {
+	result, err := db.Exec("DELETE FROM experiences WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete experience: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (db *DB) BlogPostSlugExists(slug, excludeID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM blog_posts WHERE slug = $1 AND id::text <> $2)`
+	var exists bool
+	if err := db.QueryRow(query, slug, excludeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check slug: %w", err)
+	}
+	return exists, nil
+}
+
+// UniqueBlogPostSlug returns base, or base with the first free numeric suffix when another
+// blog post than excludeID already uses it
+func (db *DB) UniqueBlogPostSlug(base, excludeID string) (string, error) {
+	candidate := base
+	for n := 2; ; n++ {
+		taken, err := db.BlogPostSlugExists(candidate, excludeID)
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug: %w", err)
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = slug.WithSuffix(base, n)
+	}
+}
+
+// CreateMedia stores an uploaded file's record together with its variants
+func (db *DB) CreateMedia(media *models.Media) (*models.Media, error) {
+	tx, err := db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create URL preview: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	return preview, nil
+	query := `
+		INSERT INTO media (storage_key, filename, content_type, size, width, height, alt_text,
+		                   blurhash, dominant_color)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRow(query,
+		media.StorageKey, media.Filename, media.ContentType, media.Size,
+		media.Width, media.Height, ptrToNullString(media.Alt),
+		ptrToNullString(media.Blurhash), ptrToNullString(media.DominantColor),
+	).Scan(&media.ID, &media.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create media: %w", err)
+	}
+
+	for _, variant := range media.Variants {
+		_, err := tx.Exec(`
+			INSERT INTO media_variants (media_id, name, storage_key, content_type, width, height, size)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, media.ID, variant.Name, variant.StorageKey, variant.ContentType, variant.Width, variant.Height, variant.Size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create media variant: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit media: %w", err)
+	}
+
+	return media, nil
 }
 
-func (db *DB) DeleteURLPreviewByMonologueID(monologueID string) error {
-	query := "DELETE FROM url_previews WHERE monologue_id = $1"
-	_, err := db.Exec(query, monologueID)
-	return err
+func (db *DB) DeleteMedia(id string) (bool, error) {
+	result, err := db.Exec("DELETE FROM media WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete media: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
 }
 
 // Helper functions
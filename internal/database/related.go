@@ -0,0 +1,108 @@
+package database
+
+import (
+	"fmt"
+)
+
+// relatedContentQuery ranks the publicly listed content against the row of table %s with
+// ID $1. Each candidate gets
+//
+//	0.6 × tag similarity: Jaccard of the tag sets, each tag weighted by its IDF
+//	0.3 × text similarity: cosine of the lexeme sets of title, excerpt and content
+//	0.1 × recency: halves every 180 days since publishing
+//
+// Tags are compared by their slugs in tag_keys, words by the lexemes in tsv. Only
+// candidates sharing a tag or a word with the source are returned, and both GIN indexes
+// are used to find them. The query ORs every lexeme of the source; each lexeme is quoted,
+// with backslashes and quotes escaped, so it is taken as it is.
+const relatedContentQuery = `
+	WITH source AS (
+		SELECT COALESCE(s.tag_keys, '{}') AS tags,
+		       tsvector_to_array(s.tsv) AS words,
+		       (SELECT string_agg('''' || replace(replace(w, '\', '\\'), '''', '''''') || '''', ' | ')
+		        FROM unnest(tsvector_to_array(s.tsv)) w)::tsquery AS query
+		FROM %s s WHERE s.id = $1
+	),
+	candidates AS (
+		SELECT 'BlogPost' AS kind, p.id, p.tag_keys AS tags, p.published_at, p.tsv
+		FROM blog_posts p CROSS JOIN source
+		WHERE p.status = 'PUBLISHED' AND p.id <> $1
+		  AND (p.tag_keys && source.tags OR p.tsv @@ source.query)
+		UNION ALL
+		SELECT 'Monologue', m.id, m.tag_keys, m.published_at, m.tsv
+		FROM monologues m CROSS JOIN source
+		WHERE m.is_published = true AND m.id <> $1
+		  AND (m.tag_keys && source.tags OR m.tsv @@ source.query)
+	),
+	candidate_tags AS (
+		SELECT DISTINCT c.kind, c.id, t.tag
+		FROM candidates c CROSS JOIN LATERAL unnest(c.tags) t(tag)
+	),
+	total AS (
+		SELECT (SELECT COUNT(*) FROM blog_posts WHERE status = 'PUBLISHED' AND id <> $1)
+		     + (SELECT COUNT(*) FROM monologues WHERE is_published = true AND id <> $1) AS n
+	),
+	idf AS (
+		SELECT t.tag, LN((total.n + 1)::float / (df.n + 1)) + 1 AS weight
+		FROM (
+			SELECT tag FROM candidate_tags
+			UNION
+			SELECT unnest(tags) FROM source
+		) t
+		CROSS JOIN total
+		CROSS JOIN LATERAL (
+			SELECT (SELECT COUNT(*) FROM blog_posts
+			        WHERE status = 'PUBLISHED' AND id <> $1 AND tag_keys @> ARRAY[t.tag])
+			     + (SELECT COUNT(*) FROM monologues
+			        WHERE is_published = true AND id <> $1 AND tag_keys @> ARRAY[t.tag]) AS n
+		) df
+	),
+	source_weight AS (
+		SELECT COALESCE(SUM(idf.weight), 0) AS total
+		FROM source CROSS JOIN LATERAL unnest(source.tags) t(tag)
+		JOIN idf ON idf.tag = t.tag
+	),
+	tag_scores AS (
+		SELECT ct.kind, ct.id,
+		       SUM(idf.weight) FILTER (WHERE ct.tag = ANY(source.tags)) AS shared,
+		       SUM(idf.weight) AS total
+		FROM candidate_tags ct
+		JOIN idf ON idf.tag = ct.tag
+		CROSS JOIN source
+		GROUP BY ct.kind, ct.id
+	),
+	scored AS (
+		SELECT c.kind, c.id, c.published_at,
+		       COALESCE(ts.shared / NULLIF(sw.total + ts.total - ts.shared, 0), 0) AS tag_score,
+		       COALESCE(
+		           (SELECT COUNT(*) FROM unnest(w.words) u(word) WHERE u.word = ANY(source.words))::float
+		           / NULLIF(SQRT(cardinality(w.words)::float * cardinality(source.words)), 0),
+		           0) AS text_score,
+		       COALESCE(POWER(0.5, EXTRACT(EPOCH FROM NOW() - c.published_at) / 86400 / 180), 0) AS recency
+		FROM candidates c
+		CROSS JOIN source
+		CROSS JOIN source_weight sw
+		CROSS JOIN LATERAL (SELECT tsvector_to_array(c.tsv) AS words) w
+		LEFT JOIN tag_scores ts ON ts.kind = c.kind AND ts.id = c.id
+	)
+	SELECT kind, id FROM scored
+	WHERE tag_score > 0 OR text_score > 0
+	ORDER BY 0.6 * tag_score + 0.3 * text_score + 0.1 * LEAST(recency, 1) DESC, published_at DESC
+	LIMIT $2
+`
+
+// GetRelatedContent returns up to limit published blog posts and monologues related to the
+// blog post or monologue of item, best match first. Returns nil when item does not exist.
+func (db *DB) GetRelatedContent(item BulkItem, limit int) ([]*ContentEntry, error) {
+	rows, err := db.Query(fmt.Sprintf(relatedContentQuery, item.table()), item.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query related content: %w", err)
+	}
+	defer rows.Close()
+
+	refs, err := scanContentRefs(rows)
+	if err != nil {
+		return nil, err
+	}
+	return db.loadContent(refs)
+}
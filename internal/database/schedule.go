@@ -0,0 +1,204 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+)
+
+// ErrNotSchedulable is returned when scheduling content that is already published
+var ErrNotSchedulable = errors.New("only unpublished content can be scheduled")
+
+// ScheduleBlogPost sets the time a draft blog post is published at. A nil at cancels the
+// schedule. Returns nil when the post does not exist.
+func (db *DB) ScheduleBlogPost(id string, at *time.Time) (*models.BlogPost, error) {
+	result, err := db.Exec(`
+		UPDATE blog_posts SET scheduled_at = $1, updated_at = NOW()
+		WHERE id = $2 AND status = 'DRAFT'
+	`, at, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule blog post: %w", err)
+	}
+
+	post, err := db.GetBlogPostByID(id)
+	if err != nil || post == nil {
+		return nil, err
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if rowsAffected == 0 && at != nil {
+		return nil, ErrNotSchedulable
+	}
+
+	return post, nil
+}
+
+// ScheduleMonologue sets the time an unpublished monologue is published at. A nil at
+// cancels the schedule. IMAGE monologues must already satisfy the publishing rules.
+// Returns nil when the monologue does not exist.
+func (db *DB) ScheduleMonologue(id string, at *time.Time) (*models.Monologue, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE monologues SET scheduled_at = $1, updated_at = NOW()
+		WHERE id = $2 AND is_published = false
+	`, at, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule monologue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected > 0 && at != nil {
+		if err := checkMonologueImages(tx, id, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit schedule: %w", err)
+	}
+
+	mono, err := db.GetMonologueByID(id)
+	if err != nil || mono == nil {
+		return nil, err
+	}
+	if rowsAffected == 0 && at != nil {
+		return nil, ErrNotSchedulable
+	}
+
+	return mono, nil
+}
+
+// GetScheduledBlogPosts returns the drafts waiting to be published, soonest first
+func (db *DB) GetScheduledBlogPosts() ([]*models.BlogPost, error) {
+	query := `
+		SELECT ` + blogPostColumns + `
+		FROM blog_posts
+		WHERE scheduled_at IS NOT NULL AND status = 'DRAFT'
+		ORDER BY scheduled_at
+	`
+
+	return db.queryBlogPosts(query)
+}
+
+// GetScheduledMonologues returns the monologues waiting to be published, soonest first
+func (db *DB) GetScheduledMonologues() ([]*models.Monologue, error) {
+	query := `
+		SELECT ` + monologueColumns + `
+		FROM monologues m
+		WHERE m.scheduled_at IS NOT NULL AND m.is_published = false
+		ORDER BY m.scheduled_at
+	`
+
+	return db.queryMonologues(query)
+}
+
+// PublishDueContent publishes every blog post and monologue whose scheduled time has
+// passed and returns the IDs it published. Due rows are claimed with FOR UPDATE SKIP
+// LOCKED and the schedule is cleared in the same transaction, so when several instances
+// run the scheduler each item is published by exactly one of them.
+func (db *DB) PublishDueContent() (blogPostIDs, monologueIDs []string, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// published_at is written like everywhere else, from the application's clock
+	now := time.Now().Format(time.RFC3339)
+
+	blogPostIDs, err = queryIDs(tx, `
+		UPDATE blog_posts
+		SET status = 'PUBLISHED',
+			published_at = COALESCE(published_at, $1),
+			scheduled_at = NULL,
+			updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM blog_posts
+			WHERE scheduled_at <= NOW() AND status = 'DRAFT'
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`, now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to publish scheduled blog posts: %w", err)
+	}
+
+	dueMonologues, err := queryIDs(tx, `
+		SELECT id FROM monologues
+		WHERE scheduled_at <= NOW() AND is_published = false
+		ORDER BY scheduled_at
+		FOR UPDATE SKIP LOCKED
+	`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query scheduled monologues: %w", err)
+	}
+
+	// Monologues are published one by one so an IMAGE monologue that lost its images
+	// since it was scheduled does not hold back the others
+	for _, id := range dueMonologues {
+		if _, err := tx.Exec("SAVEPOINT scheduled_item"); err != nil {
+			return nil, nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		_, err := tx.Exec(`
+			UPDATE monologues
+			SET is_published = true, published_at = $1, scheduled_at = NULL, updated_at = NOW()
+			WHERE id = $2
+		`, now, id)
+		if err == nil {
+			err = ensurePublishable(tx, id)
+		}
+		if err != nil {
+			if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT scheduled_item"); rbErr != nil {
+				return nil, nil, fmt.Errorf("failed to roll back savepoint: %w", rbErr)
+			}
+			// Drop the schedule so the monologue is not retried on every run
+			if _, err := tx.Exec("UPDATE monologues SET scheduled_at = NULL WHERE id = $1", id); err != nil {
+				return nil, nil, fmt.Errorf("failed to clear schedule: %w", err)
+			}
+			fmt.Printf("[SCHEDULER] Failed to publish monologue %s: %v\n", id, err)
+			continue
+		}
+
+		if _, err := tx.Exec("RELEASE SAVEPOINT scheduled_item"); err != nil {
+			return nil, nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		monologueIDs = append(monologueIDs, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit scheduled publishing: %w", err)
+	}
+
+	return blogPostIDs, monologueIDs, nil
+}
+
+func queryIDs(tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -2,8 +2,11 @@ package database
 
 import (
 	"log"
+	"time"
 
 	"github.com/lib/pq"
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/urlpreview"
 )
 
 // SeedData populates the database with initial data
@@ -216,9 +219,10 @@ func (db *DB) seedBlogPosts() error {
 
 	for _, post := range posts {
 		_, err := db.Exec(`
-			INSERT INTO blog_posts (title, slug, excerpt, content, tags, status, published_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)
-		`, post.title, post.slug, post.excerpt, post.content, pq.Array(post.tags), post.status, post.publishedAt)
+			INSERT INTO blog_posts (title, slug, excerpt, content, tags, status, published_at, tag_keys)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, post.title, post.slug, post.excerpt, post.content, pq.Array(post.tags), post.status, post.publishedAt,
+			pq.Array(tagKeys(post.tags)))
 
 		if err != nil {
 			return err
@@ -296,25 +300,37 @@ func (db *DB) seedMonologues() error {
 		var id string
 		err := db.QueryRow(`
 			INSERT INTO monologues (content, content_type, code_language, code_snippet, tags, is_published, 
-								   published_at, url, category, like_count)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+								   published_at, url, category, like_count, tag_keys)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 			RETURNING id
 		`, mono.content, mono.contentType, mono.codeLanguage, mono.codeSnippet, pq.Array(mono.tags),
-			mono.isPublished, mono.publishedAt, mono.url, mono.category, mono.likeCount).Scan(&id)
+			mono.isPublished, mono.publishedAt, mono.url, mono.category, mono.likeCount,
+			pq.Array(tagKeys(mono.tags))).Scan(&id)
 
 		if err != nil {
 			return err
 		}
 
-		// Create URL preview if URL exists
+		// Cache a URL preview if URL exists. It is expired, so it is refreshed on first view.
 		if mono.url != nil {
-			_, err = db.Exec(`
-				INSERT INTO url_previews (monologue_id, title, description, image_url, site_name, url, favicon)
-				VALUES ($1, $2, $3, $4, $5, $6, $7)
-			`, id, "react-use", "Collection of essential React Hooks",
-				"https://repository-images.githubusercontent.com/146641387/38ba6700-5db6-11ea-8af8-b5b0c92e5e2b",
-				"GitHub", *mono.url, "https://github.githubassets.com/favicons/favicon.svg")
-
+			key, err := urlpreview.Normalize(*mono.url)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			err = db.SaveURLPreviewEntry(key, &urlpreview.Entry{
+				Preview: &models.URLPreview{
+					Title:       "react-use",
+					Description: seedStringPtr("Collection of essential React Hooks"),
+					ImageURL:    seedStringPtr("https://repository-images.githubusercontent.com/146641387/38ba6700-5db6-11ea-8af8-b5b0c92e5e2b"),
+					SiteName:    seedStringPtr("GitHub"),
+					URL:         *mono.url,
+					Favicon:     seedStringPtr("https://github.githubassets.com/favicons/favicon.svg"),
+					CreatedAt:   now,
+				},
+				FetchedAt: now,
+				ExpiresAt: now,
+			})
 			if err != nil {
 				return err
 			}
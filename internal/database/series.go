@@ -0,0 +1,417 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/slug"
+)
+
+// A series orders blog posts and monologues through series_items. Every item belongs to at
+// most one series. Positions only order the items of a series and may have gaps.
+
+var (
+	ErrSeriesNotFound  = errors.New("series not found")
+	ErrSeriesSlugTaken = errors.New("another series already uses this slug")
+)
+
+// SeriesNavigation locates an item among the published items of its series. Unpublished
+// items are counted at their place too when they are the item itself, so previews show
+// where a draft will appear. Position is 1-based.
+type SeriesNavigation struct {
+	Series   *models.Series
+	Position int
+	Total    int
+	Previous *ContentEntry
+	Next     *ContentEntry
+}
+
+const seriesColumns = `s.id, s.slug, s.title, s.description, s.created_at, s.updated_at`
+
+// seriesItemRefs lists the items of series $1 in order. Unless $2 is set, only published
+// items are listed, plus the item with ID $3.
+const seriesItemRefs = `
+	SELECT CASE WHEN si.blog_post_id IS NULL THEN 'Monologue' ELSE 'BlogPost' END,
+	       COALESCE(si.blog_post_id, si.monologue_id)
+	FROM series_items si
+	LEFT JOIN blog_posts b ON b.id = si.blog_post_id
+	LEFT JOIN monologues m ON m.id = si.monologue_id
+	WHERE si.series_id = $1
+	  AND ($2 OR b.status = 'PUBLISHED' OR m.is_published
+	       OR COALESCE(si.blog_post_id, si.monologue_id)::text = $3)
+	ORDER BY si.position
+`
+
+// GetSeriesList returns all series by title
+func (db *DB) GetSeriesList() ([]*models.Series, error) {
+	rows, err := db.Query(`SELECT ` + seriesColumns + ` FROM series s ORDER BY s.title, s.created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*models.Series{}
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan series: %w", err)
+		}
+		list = append(list, series)
+	}
+
+	return list, rows.Err()
+}
+
+// GetSeriesBySlug returns nil when no series has the slug
+func (db *DB) GetSeriesBySlug(seriesSlug string) (*models.Series, error) {
+	return db.getSeries(`SELECT `+seriesColumns+` FROM series s WHERE s.slug = $1`, seriesSlug)
+}
+
+// GetSeriesByID returns nil when the series does not exist
+func (db *DB) GetSeriesByID(id string) (*models.Series, error) {
+	return db.getSeries(`SELECT `+seriesColumns+` FROM series s WHERE s.id = $1`, id)
+}
+
+func (db *DB) getSeries(query string, arg interface{}) (*models.Series, error) {
+	series, err := scanSeries(db.QueryRow(query, arg))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	return series, nil
+}
+
+// GetSeriesItems returns the items of a series in order. Unpublished items are only
+// included when all is set.
+func (db *DB) GetSeriesItems(seriesID string, all bool) ([]*ContentEntry, error) {
+	refs, err := db.seriesItemRefs(seriesID, all, "")
+	if err != nil {
+		return nil, err
+	}
+	return db.loadContent(refs)
+}
+
+// GetSeriesNavigation returns where item sits in its series, or nil when it is in none
+func (db *DB) GetSeriesNavigation(item BulkItem) (*SeriesNavigation, error) {
+	column := "monologue_id"
+	if item.BlogPost {
+		column = "blog_post_id"
+	}
+
+	var seriesID string
+	err := db.QueryRow("SELECT series_id FROM series_items WHERE "+column+" = $1", item.ID).Scan(&seriesID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series of item: %w", err)
+	}
+
+	series, err := db.GetSeriesByID(seriesID)
+	if err != nil || series == nil {
+		return nil, err
+	}
+
+	refs, err := db.seriesItemRefs(seriesID, false, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	nav := &SeriesNavigation{Series: series, Total: len(refs)}
+	var neighbours []contentRef
+	for i, ref := range refs {
+		if ref.id != item.ID {
+			continue
+		}
+		nav.Position = i + 1
+		if i > 0 {
+			neighbours = append(neighbours, refs[i-1])
+		}
+		if i+1 < len(refs) {
+			neighbours = append(neighbours, refs[i+1])
+		}
+	}
+
+	entries, err := db.loadContent(neighbours)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entryID(entry) == neighbours[0].id && nav.Position > 1 {
+			nav.Previous = entry
+		} else {
+			nav.Next = entry
+		}
+	}
+
+	return nav, nil
+}
+
+func (db *DB) seriesItemRefs(seriesID string, all bool, include string) ([]contentRef, error) {
+	rows, err := db.Query(seriesItemRefs, seriesID, all, include)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series items: %w", err)
+	}
+	defer rows.Close()
+
+	return scanContentRefs(rows)
+}
+
+// CreateSeries stores a new series. The slug is generated from the title when input has none.
+func (db *DB) CreateSeries(input models.CreateSeriesInput) (*models.Series, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	series, err := createSeries(tx, input.Title, input.Slug, input.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit series: %w", err)
+	}
+
+	return series, nil
+}
+
+// UpdateSeries applies the set fields of input. An empty description removes it.
+// Returns nil when the series does not exist.
+func (db *DB) UpdateSeries(id string, input models.UpdateSeriesInput) (*models.Series, error) {
+	setParts := []string{"updated_at = NOW()"}
+	args := []interface{}{}
+	argIndex := 1
+
+	if input.Title != nil {
+		setParts = append(setParts, fmt.Sprintf("title = $%d", argIndex))
+		args = append(args, *input.Title)
+		argIndex++
+	}
+	if input.Slug != nil {
+		setParts = append(setParts, fmt.Sprintf("slug = $%d", argIndex))
+		args = append(args, *input.Slug)
+		argIndex++
+	}
+	if input.Description != nil {
+		setParts = append(setParts, fmt.Sprintf("description = NULLIF($%d, '')", argIndex))
+		args = append(args, *input.Description)
+		argIndex++
+	}
+
+	query := fmt.Sprintf("UPDATE series SET %s WHERE id = $%d", joinStrings(setParts, ", "), argIndex)
+	args = append(args, id)
+
+	if _, err := db.Exec(query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrSeriesSlugTaken
+		}
+		return nil, fmt.Errorf("failed to update series: %w", err)
+	}
+
+	return db.GetSeriesByID(id)
+}
+
+// DeleteSeries removes a series. Its items are kept and no longer belong to a series.
+func (db *DB) DeleteSeries(id string) (bool, error) {
+	result, err := db.Exec("DELETE FROM series WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete series: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// SetSeriesItems replaces the items of a series with items, in order. Items that belong to
+// another series are moved out of it.
+func (db *DB) SetSeriesItems(seriesID string, items []BulkItem) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow("SELECT id FROM series WHERE id = $1 FOR UPDATE", seriesID).Scan(&seriesID)
+	if err == sql.ErrNoRows {
+		return ErrSeriesNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get series: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM series_items WHERE series_id = $1", seriesID); err != nil {
+		return fmt.Errorf("failed to clear series items: %w", err)
+	}
+
+	var postIDs, monologueIDs []string
+	for _, item := range items {
+		if item.BlogPost {
+			postIDs = append(postIDs, item.ID)
+		} else {
+			monologueIDs = append(monologueIDs, item.ID)
+		}
+	}
+	_, err = tx.Exec(`
+		DELETE FROM series_items
+		WHERE blog_post_id = ANY($1::uuid[]) OR monologue_id = ANY($2::uuid[])
+	`, pq.Array(postIDs), pq.Array(monologueIDs))
+	if err != nil {
+		return fmt.Errorf("failed to move series items: %w", err)
+	}
+
+	for position, item := range items {
+		if err := insertSeriesItem(tx, seriesID, position, item); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE series SET updated_at = NOW() WHERE id = $1", seriesID); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit series items: %w", err)
+	}
+
+	return nil
+}
+
+// insertSeriesItem adds a blog post or monologue that exists at position
+func insertSeriesItem(tx *sql.Tx, seriesID string, position int, item BulkItem) error {
+	column, table, kind := "monologue_id", "monologues", "monologue"
+	if item.BlogPost {
+		column, table, kind = "blog_post_id", "blog_posts", "blog post"
+	}
+
+	result, err := tx.Exec(fmt.Sprintf(`
+		INSERT INTO series_items (series_id, position, %s)
+		SELECT $1, $2, id FROM %s WHERE id = $3
+	`, column, table), seriesID, position, item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to add series item: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("%s %s not found", kind, item.ID)
+	}
+
+	return nil
+}
+
+// createSeries inserts a series. Without a slug, one is generated from the title and
+// suffixed until it is free.
+func createSeries(tx *sql.Tx, title string, seriesSlug, description *string) (*models.Series, error) {
+	if seriesSlug == nil {
+		base := slug.Generate(title)
+		candidate := base
+		for n := 2; ; n++ {
+			var taken bool
+			err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM series WHERE slug = $1)", candidate).Scan(&taken)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check slug: %w", err)
+			}
+			if !taken {
+				break
+			}
+			candidate = slug.WithSuffix(base, n)
+		}
+		seriesSlug = &candidate
+	}
+
+	series, err := scanSeries(tx.QueryRow(`
+		INSERT INTO series AS s (slug, title, description)
+		VALUES ($1, $2, NULLIF($3, ''))
+		RETURNING `+seriesColumns,
+		*seriesSlug, title, ptrToNullString(description),
+	))
+	if isUniqueViolation(err) {
+		return nil, ErrSeriesSlugTaken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+
+	return series, nil
+}
+
+// joinSeriesByTitle moves a monologue to the end of the series with the given title,
+// creating the series when there is none. Monologues already in it keep their place.
+func joinSeriesByTitle(tx *sql.Tx, monologueID, title string) error {
+	var seriesID string
+	err := tx.QueryRow("SELECT id FROM series WHERE title = $1 ORDER BY created_at LIMIT 1", title).Scan(&seriesID)
+	if err == sql.ErrNoRows {
+		series, err := createSeries(tx, title, nil, nil)
+		if err != nil {
+			return err
+		}
+		seriesID = series.ID
+	} else if err != nil {
+		return fmt.Errorf("failed to find series: %w", err)
+	}
+
+	var current sql.NullString
+	err = tx.QueryRow("SELECT series_id FROM series_items WHERE monologue_id = $1", monologueID).Scan(&current)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get series of monologue: %w", err)
+	}
+	if current.Valid && current.String == seriesID {
+		return nil
+	}
+
+	if err := leaveSeries(tx, monologueID); err != nil {
+		return err
+	}
+
+	var position int
+	err = tx.QueryRow("SELECT COALESCE(MAX(position) + 1, 0) FROM series_items WHERE series_id = $1", seriesID).Scan(&position)
+	if err != nil {
+		return fmt.Errorf("failed to get series length: %w", err)
+	}
+
+	return insertSeriesItem(tx, seriesID, position, BulkItem{ID: monologueID})
+}
+
+// leaveSeries removes a monologue from its series, if any
+func leaveSeries(tx *sql.Tx, monologueID string) error {
+	if _, err := tx.Exec("DELETE FROM series_items WHERE monologue_id = $1", monologueID); err != nil {
+		return fmt.Errorf("failed to remove monologue from series: %w", err)
+	}
+	return nil
+}
+
+func scanSeries(row interface{ Scan(...interface{}) error }) (*models.Series, error) {
+	series := &models.Series{}
+	var description sql.NullString
+	err := row.Scan(&series.ID, &series.Slug, &series.Title, &description, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	series.Description = nullStringToPtr(description)
+	return series, nil
+}
+
+// entryID is the database ID of the blog post or monologue of entry
+func entryID(entry *ContentEntry) string {
+	if entry.BlogPost != nil {
+		return entry.BlogPost.ID
+	}
+	return entry.Monologue.ID
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -2,10 +2,13 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/urlpreview"
 )
 
 // Profile methods
@@ -52,9 +55,28 @@ func (db *DB) GetDefaultProfile() (*models.Profile, error) {
 }
 
 func (db *DB) GetSocialLinks(profileID string) ([]*models.SocialLink, error) {
-	query := `SELECT platform, url, icon FROM social_links WHERE profile_id = $1`
+	query := `SELECT id, platform, url, icon FROM social_links WHERE profile_id = $1 ORDER BY created_at`
 	
-	rows, err := db.Query(query, profileID)
+	return db.querySocialLinks(query, profileID)
+}
+
+func (db *DB) GetSocialLinkByID(id string) (*models.SocialLink, error) {
+	query := `SELECT id, platform, url, icon FROM social_links WHERE id = $1`
+	
+	links, err := db.querySocialLinks(query, id)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(links) == 0 {
+		return nil, nil
+	}
+	
+	return links[0], nil
+}
+
+func (db *DB) querySocialLinks(query string, args ...interface{}) ([]*models.SocialLink, error) {
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +87,7 @@ func (db *DB) GetSocialLinks(profileID string) ([]*models.SocialLink, error) {
 		link := &models.SocialLink{}
 		var icon sql.NullString
 		
-		err := rows.Scan(&link.Platform, &link.URL, &icon)
+		err := rows.Scan(&link.ID, &link.Platform, &link.URL, &icon)
 		if err != nil {
 			return nil, err
 		}
@@ -84,7 +106,29 @@ func (db *DB) GetSkills() ([]*models.Skill, error) {
 		FROM skills ORDER BY display_order, name
 	`
 	
-	rows, err := db.Query(query)
+	return db.querySkills(query)
+}
+
+func (db *DB) GetSkillByID(id string) (*models.Skill, error) {
+	query := `
+		SELECT id, name, category, level, icon_url, display_order, created_at, updated_at
+		FROM skills WHERE id = $1
+	`
+	
+	skills, err := db.querySkills(query, id)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(skills) == 0 {
+		return nil, nil
+	}
+	
+	return skills[0], nil
+}
+
+func (db *DB) querySkills(query string, args ...interface{}) ([]*models.Skill, error) {
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +162,30 @@ func (db *DB) GetExperiences() ([]*models.Experience, error) {
 		FROM experiences ORDER BY is_current DESC, start_date DESC
 	`
 	
-	rows, err := db.Query(query)
+	return db.queryExperiences(query)
+}
+
+func (db *DB) GetExperienceByID(id string) (*models.Experience, error) {
+	query := `
+		SELECT id, company, position, description, start_date, end_date, 
+			   is_current, technologies, created_at, updated_at
+		FROM experiences WHERE id = $1
+	`
+	
+	experiences, err := db.queryExperiences(query, id)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(experiences) == 0 {
+		return nil, nil
+	}
+	
+	return experiences[0], nil
+}
+
+func (db *DB) queryExperiences(query string, args ...interface{}) ([]*models.Experience, error) {
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -147,10 +214,15 @@ func (db *DB) GetExperiences() ([]*models.Experience, error) {
 }
 
 // Blog Posts methods
+
+// blogPostColumns is the column list queryBlogPosts scans
+const blogPostColumns = `id, title, slug, excerpt, content, cover_image_url, tags,
+			   status, seo_title, seo_description, published_at, like_count,
+			   reading_time, character_count, scheduled_at, locale, created_at, updated_at`
+
 func (db *DB) GetBlogPosts() ([]*models.BlogPost, error) {
 	query := `
-		SELECT id, title, slug, excerpt, content, cover_image_url, tags,
-			   status, seo_title, seo_description, published_at, like_count, created_at, updated_at
+		SELECT ` + blogPostColumns + `
 		FROM blog_posts WHERE status = 'PUBLISHED' ORDER BY published_at DESC
 	`
 	
@@ -159,19 +231,19 @@ func (db *DB) GetBlogPosts() ([]*models.BlogPost, error) {
 
 func (db *DB) GetAdminBlogPosts() ([]*models.BlogPost, error) {
 	query := `
-		SELECT id, title, slug, excerpt, content, cover_image_url, tags,
-			   status, seo_title, seo_description, published_at, like_count, created_at, updated_at
+		SELECT ` + blogPostColumns + `
 		FROM blog_posts ORDER BY created_at DESC
 	`
 	
 	return db.queryBlogPosts(query)
 }
 
+// GetBlogPostBySlug returns a published or archived post. Archived posts are left out of
+// lists but links to them keep working.
 func (db *DB) GetBlogPostBySlug(slug string) (*models.BlogPost, error) {
 	query := `
-		SELECT id, title, slug, excerpt, content, cover_image_url, tags,
-			   status, seo_title, seo_description, published_at, like_count, created_at, updated_at
-		FROM blog_posts WHERE slug = $1 AND status = 'PUBLISHED'
+		SELECT ` + blogPostColumns + `
+		FROM blog_posts WHERE slug = $1 AND status IN ('PUBLISHED', 'ARCHIVED')
 	`
 	
 	posts, err := db.queryBlogPosts(query, slug)
@@ -186,10 +258,26 @@ func (db *DB) GetBlogPostBySlug(slug string) (*models.BlogPost, error) {
 	return posts[0], nil
 }
 
+// GetBlogPostIDBySlugHistory returns the ID of the blog post that previously used slug,
+// or "" when no post did
+func (db *DB) GetBlogPostIDBySlugHistory(slug string) (string, error) {
+	query := `SELECT blog_post_id FROM blog_post_slug_history WHERE slug = $1`
+	
+	var id string
+	err := db.QueryRow(query, slug).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	
+	return id, nil
+}
+
 func (db *DB) GetBlogPostByID(id string) (*models.BlogPost, error) {
 	query := `
-		SELECT id, title, slug, excerpt, content, cover_image_url, tags,
-			   status, seo_title, seo_description, published_at, like_count, created_at, updated_at
+		SELECT ` + blogPostColumns + `
 		FROM blog_posts WHERE id = $1
 	`
 	
@@ -215,13 +303,14 @@ func (db *DB) queryBlogPosts(query string, args ...interface{}) ([]*models.BlogP
 	var posts []*models.BlogPost
 	for rows.Next() {
 		post := &models.BlogPost{}
-		var excerpt, coverImageURL, seoTitle, seoDescription, publishedAt sql.NullString
+		var excerpt, coverImageURL, seoTitle, seoDescription, publishedAt, scheduledAt sql.NullString
 		var likeCount sql.NullInt64
 		
 		err := rows.Scan(
 			&post.ID, &post.Title, &post.Slug, &excerpt, &post.Content,
 			&coverImageURL, pq.Array(&post.Tags), &post.Status,
 			&seoTitle, &seoDescription, &publishedAt, &likeCount,
+			&post.ReadingTime, &post.CharacterCount, &scheduledAt, &post.OriginalLocale,
 			&post.CreatedAt, &post.UpdatedAt,
 		)
 		if err != nil {
@@ -233,6 +322,8 @@ func (db *DB) queryBlogPosts(query string, args ...interface{}) ([]*models.BlogP
 		post.SeoTitle = nullStringToPtr(seoTitle)
 		post.SeoDescription = nullStringToPtr(seoDescription)
 		post.PublishedAt = nullStringToPtr(publishedAt)
+		post.ScheduledAt = nullStringToPtr(scheduledAt)
+		post.Locale = post.OriginalLocale
 		
 		if likeCount.Valid {
 			count := int(likeCount.Int64)
@@ -266,21 +357,50 @@ func (db *DB) GetBlogPostLikeCount(blogPostID string) (int, error) {
 }
 
 // Monologues methods
-func (db *DB) GetMonologues(limit, offset *int, tags []string) ([]*models.Monologue, error) {
+
+// monologueColumns is the column list queryMonologues scans, qualified by the alias m.
+// The series is the title of the series the monologue belongs to, reply_count counts its
+// published direct replies.
+const monologueColumns = `m.id, m.content, m.content_type, m.code_language, m.code_snippet,
+			   m.tags, m.is_published, m.published_at, m.url,
+			   (SELECT s.title FROM series_items si JOIN series s ON s.id = si.series_id
+			    WHERE si.monologue_id = m.id) AS series,
+			   m.category, m.code_category_id, m.difficulty, m.parent_id,
+			   (SELECT COUNT(*) FROM monologues r
+			    WHERE r.parent_id = m.id AND r.is_published = true) AS reply_count,
+			   m.like_count, m.reading_time, m.character_count, m.scheduled_at,
+			   m.created_at, m.updated_at`
+
+// GetMonologues returns published monologues, newest first. A category matches its
+// monologues and those of all its descendants. tags are tag slugs. rootsOnly leaves out
+// replies.
+func (db *DB) GetMonologues(limit, offset *int, categoryID *string, tags []string, difficulty *models.Difficulty, rootsOnly bool) ([]*models.Monologue, error) {
 	query := `
-		SELECT m.id, m.content, m.content_type, m.code_language, m.code_snippet,
-			   m.tags, m.is_published, m.published_at, m.url, m.series, m.category,
-			   m.like_count, m.created_at, m.updated_at
+		SELECT ` + monologueColumns + `
 		FROM monologues m
 		WHERE m.is_published = true
 	`
 	args := []interface{}{}
 	argIndex := 1
 	
+	if categoryID != nil {
+		query += " AND m.code_category_id IN (" + fmt.Sprintf(categorySubtree, fmt.Sprintf("$%d", argIndex)) + ")"
+		args = append(args, *categoryID)
+		argIndex++
+	}
 	
+	if difficulty != nil {
+		query += fmt.Sprintf(" AND m.difficulty = $%d", argIndex)
+		args = append(args, string(*difficulty))
+		argIndex++
+	}
+	
+	if rootsOnly {
+		query += " AND m.parent_id IS NULL"
+	}
 	
 	if len(tags) > 0 {
-		query += fmt.Sprintf(" AND m.tags && $%d", argIndex)
+		query += fmt.Sprintf(" AND m.tag_keys && $%d", argIndex)
 		args = append(args, pq.Array(tags))
 		argIndex++
 	}
@@ -303,9 +423,7 @@ func (db *DB) GetMonologues(limit, offset *int, tags []string) ([]*models.Monolo
 
 func (db *DB) GetAdminMonologues() ([]*models.Monologue, error) {
 	query := `
-		SELECT m.id, m.content, m.content_type, m.code_language, m.code_snippet,
-			   m.tags, m.is_published, m.published_at, m.url, m.series, m.category,
-			   m.like_count, m.created_at, m.updated_at
+		SELECT ` + monologueColumns + `
 		FROM monologues m
 		ORDER BY m.created_at DESC
 	`
@@ -315,9 +433,7 @@ func (db *DB) GetAdminMonologues() ([]*models.Monologue, error) {
 
 func (db *DB) GetMonologueByID(id string) (*models.Monologue, error) {
 	query := `
-		SELECT m.id, m.content, m.content_type, m.code_language, m.code_snippet,
-			   m.tags, m.is_published, m.published_at, m.url, m.series, m.category,
-			   m.like_count, m.created_at, m.updated_at
+		SELECT ` + monologueColumns + `
 		FROM monologues m
 		WHERE m.id = $1
 	`
@@ -344,14 +460,14 @@ func (db *DB) queryMonologues(query string, args ...interface{}) ([]*models.Mono
 	var monologues []*models.Monologue
 	for rows.Next() {
 		mono := &models.Monologue{}
-		var codeLanguage, codeSnippet, publishedAt, url, series, category sql.NullString
+		var codeLanguage, codeSnippet, publishedAt, url, series, category, scheduledAt sql.NullString
+		var codeCategoryID, difficulty, parentID sql.NullString
 		var likeCount sql.NullInt64
 		
-		
 		err := rows.Scan(
 			&mono.ID, &mono.Content, &mono.ContentType, &codeLanguage, &codeSnippet,
 			pq.Array(&mono.Tags), &mono.IsPublished, &publishedAt, &url, &series, &category,
-			&likeCount, &mono.CreatedAt, &mono.UpdatedAt,
+			&codeCategoryID, &difficulty, &parentID, &mono.ReplyCount, &likeCount, &mono.ReadingTime, &mono.CharacterCount, &scheduledAt, &mono.CreatedAt, &mono.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -360,58 +476,363 @@ func (db *DB) queryMonologues(query string, args ...interface{}) ([]*models.Mono
 		mono.CodeLanguage = nullStringToPtr(codeLanguage)
 		mono.CodeSnippet = nullStringToPtr(codeSnippet)
 		mono.PublishedAt = nullStringToPtr(publishedAt)
+		mono.ScheduledAt = nullStringToPtr(scheduledAt)
 		mono.URL = nullStringToPtr(url)
 		mono.Series = nullStringToPtr(series)
 		mono.Category = nullStringToPtr(category)
-		
+		mono.CodeCategoryID = nullStringToPtr(codeCategoryID)
+		mono.ParentID = nullStringToPtr(parentID)
+		if difficulty.Valid {
+			d := models.Difficulty(difficulty.String)
+			mono.Difficulty = &d
+		}
 		
 		if likeCount.Valid {
 			count := int(likeCount.Int64)
 			mono.LikeCount = &count
 		}
 		
-		
-		// Load URL preview if URL exists
-		if mono.URL != nil {
-			urlPreview, _ := db.GetURLPreviewByMonologueID(mono.ID)
-			mono.URLPreview = urlPreview
-		}
-		
 		monologues = append(monologues, mono)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	
+	if err := db.attachURLPreviews(monologues); err != nil {
+		return nil, err
+	}
+	if err := db.attachImages(monologues); err != nil {
+		return nil, err
+	}
 	
 	return monologues, nil
 }
 
 
 // URL Preview methods
-func (db *DB) GetURLPreviewByMonologueID(monologueID string) (*models.URLPreview, error) {
+
+// linkPreviewColumns is the column list scanURLPreviewEntry reads
+const linkPreviewColumns = `url, title, description, image_url, site_name, favicon, error,
+			   fetched_at, expires_at, failed_at`
+
+// GetURLPreviewEntry returns the cached preview for a normalized URL, or nil when the URL
+// has never been fetched
+func (db *DB) GetURLPreviewEntry(key string) (*urlpreview.Entry, error) {
+	row := db.QueryRow("SELECT "+linkPreviewColumns+" FROM link_previews WHERE normalized_url = $1", key)
+	entry, err := scanURLPreviewEntry(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return entry, err
+}
+
+// ExpiredURLPreviewKeys returns up to limit normalized URLs whose preview expired, leaving
+// out those whose last fetch failed after failedBefore, oldest first
+func (db *DB) ExpiredURLPreviewKeys(failedBefore time.Time, limit int) ([]string, error) {
+	rows, err := db.Query(`
+		SELECT normalized_url FROM link_previews
+		WHERE expires_at < NOW() AND (failed_at IS NULL OR failed_at < $1)
+		ORDER BY expires_at
+		LIMIT $2
+	`, failedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired url previews: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan url preview key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// attachURLPreviews sets the stored preview of every monologue with a URL, in one query.
+// Nothing is fetched here: URLs without a stored preview are left without one until the
+// scheduler or an edit of the monologue fetches it.
+func (db *DB) attachURLPreviews(monologues []*models.Monologue) error {
+	byKey := map[string][]*models.Monologue{}
+	for _, mono := range monologues {
+		if mono.URL == nil {
+			continue
+		}
+		if key, err := urlpreview.Normalize(*mono.URL); err == nil {
+			byKey[key] = append(byKey[key], mono)
+		}
+	}
+	if len(byKey) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+
+	rows, err := db.Query(`
+		SELECT normalized_url, `+linkPreviewColumns+`
+		FROM link_previews WHERE normalized_url = ANY($1::text[]) AND error IS NULL
+	`, pq.Array(keys))
+	if err != nil {
+		return fmt.Errorf("failed to query url previews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		entry, err := scanURLPreviewEntry(rows, &key)
+		if err != nil {
+			return err
+		}
+		for _, mono := range byKey[key] {
+			mono.URLPreview = entry.Preview
+		}
+	}
+	return rows.Err()
+}
+
+// scanURLPreviewEntry reads the linkPreviewColumns, preceded by the destinations in prefix
+func scanURLPreviewEntry(row interface{ Scan(...interface{}) error }, prefix ...interface{}) (*urlpreview.Entry, error) {
+	entry := &urlpreview.Entry{}
+	var pageURL string
+	var title, description, imageURL, siteName, favicon, fetchErr sql.NullString
+	var failedAt sql.NullTime
+
+	dest := append(prefix,
+		&pageURL, &title, &description, &imageURL, &siteName, &favicon,
+		&fetchErr, &entry.FetchedAt, &entry.ExpiresAt, &failedAt,
+	)
+	if err := row.Scan(dest...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan url preview: %w", err)
+	}
+
+	if failedAt.Valid {
+		entry.FailedAt = &failedAt.Time
+	}
+	if fetchErr.Valid {
+		entry.Err = fetchErr.String
+		return entry, nil
+	}
+
+	entry.Preview = &models.URLPreview{
+		Title:       title.String,
+		Description: nullStringToPtr(description),
+		ImageURL:    nullStringToPtr(imageURL),
+		SiteName:    nullStringToPtr(siteName),
+		URL:         pageURL,
+		Favicon:     nullStringToPtr(favicon),
+		CreatedAt:   entry.FetchedAt,
+	}
+	return entry, nil
+}
+
+// attachImages sets the images of every monologue in display order, in one query
+func (db *DB) attachImages(monologues []*models.Monologue) error {
+	if len(monologues) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Monologue, len(monologues))
+	ids := make([]string, len(monologues))
+	for i, mono := range monologues {
+		mono.Images = []*models.MonologueImage{}
+		byID[mono.ID] = mono
+		ids[i] = mono.ID
+	}
+
 	query := `
-		SELECT title, description, image_url, site_name, url, favicon, created_at
-		FROM url_previews WHERE monologue_id = $1
+		SELECT monologue_id, media_id, url, alt_text, width, height, blurhash, dominant_color, caption
+		FROM monologue_images
+		WHERE monologue_id = ANY($1::uuid[])
+		ORDER BY monologue_id, position
 	`
 	
-	preview := &models.URLPreview{}
-	var description, imageURL, siteName, favicon sql.NullString
+	rows, err := db.Query(query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query monologue images: %w", err)
+	}
+	defer rows.Close()
 	
-	err := db.QueryRow(query, monologueID).Scan(
-		&preview.Title, &description, &imageURL, &siteName,
-		&preview.URL, &favicon, &preview.CreatedAt,
-	)
+	for rows.Next() {
+		image := &models.MonologueImage{}
+		var monologueID string
+		var mediaID, alt, blurhash, dominantColor, caption sql.NullString
+		var width, height sql.NullInt64
+		if err := rows.Scan(&monologueID, &mediaID, &image.URL, &alt, &width, &height, &blurhash, &dominantColor, &caption); err != nil {
+			return fmt.Errorf("failed to scan monologue image: %w", err)
+		}
+		image.MediaID = nullStringToPtr(mediaID)
+		image.Alt = nullStringToPtr(alt)
+		image.Width = nullInt64ToIntPtr(width)
+		image.Height = nullInt64ToIntPtr(height)
+		image.Blurhash = nullStringToPtr(blurhash)
+		image.DominantColor = nullStringToPtr(dominantColor)
+		image.Caption = nullStringToPtr(caption)
+		if mono := byID[monologueID]; mono != nil {
+			mono.Images = append(mono.Images, image)
+		}
+	}
 	
+	return rows.Err()
+}
+
+func (db *DB) GetMediaList(limit, offset int) ([]*models.Media, error) {
+	query := `
+		SELECT id, storage_key, filename, content_type, size, width, height, alt_text,
+		       blurhash, dominant_color, created_at
+		FROM media
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+	
+	rows, err := db.Query(query, limit, offset)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+		return nil, fmt.Errorf("failed to query media: %w", err)
+	}
+	defer rows.Close()
+	
+	var list []*models.Media
+	byID := map[string]*models.Media{}
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
 		}
+		list = append(list, media)
+		byID[media.ID] = media
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	
-	preview.Description = nullStringToPtr(description)
-	preview.ImageURL = nullStringToPtr(imageURL)
-	preview.SiteName = nullStringToPtr(siteName)
-	preview.Favicon = nullStringToPtr(favicon)
+	if len(list) == 0 {
+		return list, nil
+	}
+	
+	ids := make([]string, 0, len(list))
+	for _, media := range list {
+		ids = append(ids, media.ID)
+	}
+	
+	variantRows, err := db.Query(`
+		SELECT media_id, name, storage_key, content_type, width, height, size
+		FROM media_variants
+		WHERE media_id = ANY($1::uuid[])
+		ORDER BY name
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query media variants: %w", err)
+	}
+	defer variantRows.Close()
+	
+	for variantRows.Next() {
+		var mediaID string
+		variant := &models.MediaVariant{}
+		if err := variantRows.Scan(&mediaID, &variant.Name, &variant.StorageKey, &variant.ContentType,
+			&variant.Width, &variant.Height, &variant.Size); err != nil {
+			return nil, fmt.Errorf("failed to scan media variant: %w", err)
+		}
+		if media := byID[mediaID]; media != nil {
+			media.Variants = append(media.Variants, variant)
+		}
+	}
+	
+	return list, variantRows.Err()
+}
+
+func (db *DB) GetMediaByID(id string) (*models.Media, error) {
+	query := `
+		SELECT id, storage_key, filename, content_type, size, width, height, alt_text,
+		       blurhash, dominant_color, created_at
+		FROM media WHERE id = $1
+	`
+	
+	media, err := scanMedia(db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	rows, err := db.Query(`
+		SELECT name, storage_key, content_type, width, height, size
+		FROM media_variants WHERE media_id = $1 ORDER BY name
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query media variants: %w", err)
+	}
+	defer rows.Close()
+	
+	for rows.Next() {
+		variant := &models.MediaVariant{}
+		if err := rows.Scan(&variant.Name, &variant.StorageKey, &variant.ContentType,
+			&variant.Width, &variant.Height, &variant.Size); err != nil {
+			return nil, fmt.Errorf("failed to scan media variant: %w", err)
+		}
+		media.Variants = append(media.Variants, variant)
+	}
 	
-	return preview, nil
+	return media, rows.Err()
+}
+
+// GetMediaUsages lists the content that references any of the given URLs, so unused
+// uploads can be told apart from ones still shown on the site
+func (db *DB) GetMediaUsages(urls []string) ([]*models.MediaUsage, error) {
+	query := `
+		SELECT 'BlogPost', id, title, 'coverImageUrl' FROM blog_posts WHERE cover_image_url = ANY($1)
+		UNION ALL
+		SELECT 'BlogPost', id, title, 'content' FROM blog_posts
+			WHERE EXISTS (SELECT 1 FROM unnest($1::text[]) u WHERE strpos(content, u) > 0)
+		UNION ALL
+		SELECT 'Monologue', id, left(content, 100), 'content' FROM monologues
+			WHERE EXISTS (SELECT 1 FROM unnest($1::text[]) u WHERE strpos(content, u) > 0)
+		UNION ALL
+		SELECT 'Monologue', id, left(content, 100), 'images' FROM monologues m
+			WHERE EXISTS (SELECT 1 FROM monologue_images i WHERE i.monologue_id = m.id AND i.url = ANY($1))
+		UNION ALL
+		SELECT 'Profile', id, name, 'avatarUrl' FROM profiles WHERE avatar_url = ANY($1)
+		UNION ALL
+		SELECT 'Skill', id, name, 'iconUrl' FROM skills WHERE icon_url = ANY($1)
+	`
+	
+	rows, err := db.Query(query, pq.Array(urls))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query media usages: %w", err)
+	}
+	defer rows.Close()
+	
+	var usages []*models.MediaUsage
+	for rows.Next() {
+		usage := &models.MediaUsage{}
+		if err := rows.Scan(&usage.Type, &usage.ID, &usage.Title, &usage.Field); err != nil {
+			return nil, fmt.Errorf("failed to scan media usage: %w", err)
+		}
+		usages = append(usages, usage)
+	}
+	
+	return usages, rows.Err()
+}
+
+func scanMedia(row interface{ Scan(...interface{}) error }) (*models.Media, error) {
+	media := &models.Media{}
+	var alt, blurhash, dominantColor sql.NullString
+	err := row.Scan(&media.ID, &media.StorageKey, &media.Filename, &media.ContentType,
+		&media.Size, &media.Width, &media.Height, &alt, &blurhash, &dominantColor, &media.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	media.Alt = nullStringToPtr(alt)
+	media.Blurhash = nullStringToPtr(blurhash)
+	media.DominantColor = nullStringToPtr(dominantColor)
+	return media, nil
 }
 
 // Helper functions
@@ -436,3 +857,10 @@ func ptrToNullInt(i *int) sql.NullInt64 {
 	return sql.NullInt64{Int64: int64(*i), Valid: true}
 }
 
+func nullInt64ToIntPtr(ni sql.NullInt64) *int {
+	if !ni.Valid {
+		return nil
+	}
+	i := int(ni.Int64)
+	return &i
+}
@@ -0,0 +1,536 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lib/pq"
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/slug"
+)
+
+// Content keeps its tags as TEXT[], and their slug.Tag keys in tag_keys. The tags table
+// only holds the name and description admins gave a tag, keyed by slug.Tag of the name;
+// every spelling in content with the same slug belongs to that tag.
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("another tag already has this name, merge the tags instead")
+)
+
+// GetTags returns the tags of published content and the tags admins described, most
+// used first
+func (db *DB) GetTags() ([]*models.Tag, error) {
+	query := `
+		SELECT tag, kind, COUNT(*) FROM (
+			SELECT unnest(tags) AS tag, 'BLOG' AS kind FROM blog_posts WHERE status = 'PUBLISHED'
+			UNION ALL
+			SELECT unnest(tags), content_type FROM monologues WHERE is_published = true
+		) t
+		GROUP BY tag, kind
+		ORDER BY tag, kind
+	`
+
+	bySlug, err := db.tagUsage(query)
+	if err != nil {
+		return nil, err
+	}
+
+	infoRows, err := db.Query("SELECT slug, name, description FROM tags")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer infoRows.Close()
+
+	for infoRows.Next() {
+		var key, name string
+		var description sql.NullString
+		if err := infoRows.Scan(&key, &name, &description); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tag := bySlug[key]
+		if tag == nil {
+			tag = &models.Tag{Slug: key, Counts: []*models.TagCount{}}
+			bySlug[key] = tag
+		}
+		tag.Name = name
+		tag.Description = nullStringToPtr(description)
+	}
+	if err := infoRows.Err(); err != nil {
+		return nil, err
+	}
+
+	tags := make([]*models.Tag, 0, len(bySlug))
+	for _, tag := range bySlug {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Slug < tags[j].Slug
+	})
+
+	return tags, nil
+}
+
+// GetTag returns the tag with the given slug, or nil when no content uses it and it has
+// not been described
+func (db *DB) GetTag(tagSlug string) (*models.Tag, error) {
+	query := `
+		SELECT tag, kind, COUNT(*) FROM (
+			SELECT unnest(tags) AS tag, 'BLOG' AS kind FROM blog_posts
+			WHERE status = 'PUBLISHED' AND tag_keys @> ARRAY[$1]::text[]
+			UNION ALL
+			SELECT unnest(tags), content_type FROM monologues
+			WHERE is_published = true AND tag_keys @> ARRAY[$1]::text[]
+		) t
+		GROUP BY tag, kind
+		ORDER BY tag, kind
+	`
+
+	// The rows using the tag also bring their other tags along
+	bySlug, err := db.tagUsage(query, tagSlug)
+	if err != nil {
+		return nil, err
+	}
+	tag := bySlug[tagSlug]
+
+	var name string
+	var description sql.NullString
+	err = db.QueryRow("SELECT name, description FROM tags WHERE slug = $1", tagSlug).Scan(&name, &description)
+	if err == sql.ErrNoRows {
+		return tag, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tag: %w", err)
+	}
+
+	if tag == nil {
+		tag = &models.Tag{Slug: tagSlug, Counts: []*models.TagCount{}}
+	}
+	tag.Name = name
+	tag.Description = nullStringToPtr(description)
+
+	return tag, nil
+}
+
+// GetBlogPostsByTag returns the published posts using any spelling of the tag, newest first
+func (db *DB) GetBlogPostsByTag(tag *models.Tag) ([]*models.BlogPost, error) {
+	query := `
+		SELECT ` + blogPostColumns + `
+		FROM blog_posts
+		WHERE status = 'PUBLISHED' AND tag_keys @> ARRAY[$1]::text[]
+		ORDER BY published_at DESC
+	`
+
+	return db.queryBlogPosts(query, tag.Slug)
+}
+
+// GetMonologuesByTag returns the published monologues using any spelling of the tag,
+// newest first
+func (db *DB) GetMonologuesByTag(tag *models.Tag) ([]*models.Monologue, error) {
+	query := `
+		SELECT ` + monologueColumns + `
+		FROM monologues m
+		WHERE m.is_published = true AND m.tag_keys @> ARRAY[$1]::text[]
+		ORDER BY m.published_at DESC
+	`
+
+	return db.queryMonologues(query, tag.Slug)
+}
+
+// tagUsage groups the tag, kind and count rows of query by tag slug. Each tag is named
+// after its most used spelling.
+func (db *DB) tagUsage(query string, args ...interface{}) (map[string]*models.Tag, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tag usage: %w", err)
+	}
+	defer rows.Close()
+
+	bySlug := map[string]*models.Tag{}
+	uses := map[string]map[string]int{}
+	for rows.Next() {
+		var name string
+		var kind models.ContentType
+		var count int
+		if err := rows.Scan(&name, &kind, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag usage: %w", err)
+		}
+
+		key := slug.Tag(name)
+		if key == "" {
+			continue
+		}
+		tag := bySlug[key]
+		if tag == nil {
+			tag = &models.Tag{Slug: key, Counts: []*models.TagCount{}}
+			bySlug[key] = tag
+			uses[key] = map[string]int{}
+		}
+		if uses[key][name] == 0 {
+			tag.Variants = append(tag.Variants, name)
+		}
+		uses[key][name] += count
+		tag.Count += count
+		addTagCount(tag, kind, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for key, tag := range bySlug {
+		tag.Name = preferredTagName(uses[key])
+	}
+
+	return bySlug, nil
+}
+
+// RenameTag rewrites every spelling of the tag in all content, drafts included, to name
+func (db *DB) RenameTag(tagSlug, name string) (*models.Tag, error) {
+	newSlug := slug.Tag(name)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	variants, err := tagVariants(tx, []string{tagSlug})
+	if err != nil {
+		return nil, err
+	}
+	_, description, found, err := tagInfo(tx, tagSlug)
+	if err != nil {
+		return nil, err
+	}
+	if len(variants) == 0 && !found {
+		return nil, ErrTagNotFound
+	}
+
+	if newSlug != tagSlug {
+		taken, err := tagVariants(tx, []string{newSlug})
+		if err != nil {
+			return nil, err
+		}
+		_, _, takenInfo, err := tagInfo(tx, newSlug)
+		if err != nil {
+			return nil, err
+		}
+		if len(taken) > 0 || takenInfo {
+			return nil, ErrTagExists
+		}
+	}
+
+	if err := rewriteTags(tx, keys(variants), name); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("DELETE FROM tags WHERE slug = $1", tagSlug); err != nil {
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+	if err := saveTagInfo(tx, newSlug, name, description); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tag rename: %w", err)
+	}
+
+	return db.GetTag(newSlug)
+}
+
+// MergeTags replaces the source tags with the target tag in all content, drafts included.
+// The target keeps its name and description; without a description it takes the first
+// one among the sources.
+func (db *DB) MergeTags(sources []string, target string) (*models.Tag, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	targetVariants, err := tagVariants(tx, []string{target})
+	if err != nil {
+		return nil, err
+	}
+	name, description, found, err := tagInfo(tx, target)
+	if err != nil {
+		return nil, err
+	}
+	if len(targetVariants) == 0 && !found {
+		return nil, ErrTagNotFound
+	}
+	if !found {
+		name = preferredTagName(targetVariants)
+	}
+
+	var others []string
+	for _, source := range sources {
+		if source != target {
+			others = append(others, source)
+		}
+	}
+
+	sourceVariants, err := tagVariants(tx, others)
+	if err != nil {
+		return nil, err
+	}
+	for _, source := range others {
+		_, sourceDescription, _, err := tagInfo(tx, source)
+		if err != nil {
+			return nil, err
+		}
+		if description == nil {
+			description = sourceDescription
+		}
+	}
+
+	// Other spellings of the target are unified as well
+	variants := append(keys(sourceVariants), keys(targetVariants)...)
+	if err := rewriteTags(tx, variants, name); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("DELETE FROM tags WHERE slug = ANY($1::text[])", pq.Array(others)); err != nil {
+		return nil, fmt.Errorf("failed to delete merged tags: %w", err)
+	}
+	if err := saveTagInfo(tx, target, name, description); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tag merge: %w", err)
+	}
+
+	return db.GetTag(target)
+}
+
+// DescribeTag sets the description of a tag. A nil description removes it.
+func (db *DB) DescribeTag(tagSlug string, description *string) (*models.Tag, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	variants, err := tagVariants(tx, []string{tagSlug})
+	if err != nil {
+		return nil, err
+	}
+	name, _, found, err := tagInfo(tx, tagSlug)
+	if err != nil {
+		return nil, err
+	}
+	if len(variants) == 0 && !found {
+		return nil, ErrTagNotFound
+	}
+	if !found {
+		name = preferredTagName(variants)
+	}
+
+	if err := saveTagInfo(tx, tagSlug, name, description); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit tag description: %w", err)
+	}
+
+	return db.GetTag(tagSlug)
+}
+
+// tagVariants returns the spellings used anywhere in content for the given tag slugs,
+// with how often each is used
+func tagVariants(tx *sql.Tx, slugs []string) (map[string]int, error) {
+	variants := map[string]int{}
+	if len(slugs) == 0 {
+		return variants, nil
+	}
+
+	wanted := make(map[string]bool, len(slugs))
+	for _, s := range slugs {
+		wanted[s] = true
+	}
+
+	rows, err := tx.Query(`
+		SELECT tag, COUNT(*) FROM (
+			SELECT unnest(tags) AS tag FROM blog_posts WHERE tag_keys && $1::text[]
+			UNION ALL
+			SELECT unnest(tags) FROM monologues WHERE tag_keys && $1::text[]
+		) t
+		GROUP BY tag
+	`, pq.Array(slugs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var count int
+		if err := rows.Scan(&name, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		if wanted[slug.Tag(name)] {
+			variants[name] = count
+		}
+	}
+
+	return variants, rows.Err()
+}
+
+// rewriteTags replaces every tag in variants with name, keeping the position of the
+// first replaced tag and dropping duplicates. Renaming a tag does not edit the content,
+// so updated_at is left as it is.
+func rewriteTags(tx *sql.Tx, variants []string, name string) error {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	for _, table := range []string{"blog_posts", "monologues"} {
+		query := fmt.Sprintf(`
+			UPDATE %s
+			SET tags = ARRAY(
+					SELECT t FROM (
+						SELECT CASE WHEN u.t = ANY($1::text[]) THEN $2 ELSE u.t END AS t, MIN(u.i) AS i
+						FROM unnest(tags) WITH ORDINALITY AS u(t, i)
+						GROUP BY 1
+					) rewritten
+					ORDER BY i
+				)
+			WHERE tags && $1::text[]
+			RETURNING id
+		`, table)
+
+		rows, err := tx.Query(query, pq.Array(variants), name)
+		if err != nil {
+			return fmt.Errorf("failed to rewrite tags in %s: %w", table, err)
+		}
+		var ids []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan rewritten %s: %w", table, err)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to rewrite tags in %s: %w", table, err)
+		}
+
+		if err := refreshTagKeys(tx, table, ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tagKeys returns the slugs of tags without duplicates, as stored in tag_keys
+func tagKeys(tags []string) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		key := slug.Tag(tag)
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// refreshTagKeys recomputes tag_keys of the given rows after their tags were changed in SQL
+func refreshTagKeys(tx *sql.Tx, table string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := tx.Query(fmt.Sprintf("SELECT id, tags FROM %s WHERE id = ANY($1::uuid[])", table), pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query tags of %s: %w", table, err)
+	}
+
+	keysByID := map[string][]string{}
+	for rows.Next() {
+		var id string
+		var tags pq.StringArray
+		if err := rows.Scan(&id, &tags); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan tags of %s: %w", table, err)
+		}
+		keysByID[id] = tagKeys(tags)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query tags of %s: %w", table, err)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET tag_keys = $1 WHERE id = $2", table)
+	for id, keys := range keysByID {
+		if _, err := tx.Exec(query, pq.Array(keys), id); err != nil {
+			return fmt.Errorf("failed to update tag keys of %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+func tagInfo(tx *sql.Tx, tagSlug string) (name string, description *string, found bool, err error) {
+	var desc sql.NullString
+	err = tx.QueryRow("SELECT name, description FROM tags WHERE slug = $1", tagSlug).Scan(&name, &desc)
+	if err == sql.ErrNoRows {
+		return "", nil, false, nil
+	}
+	if err != nil {
+		return "", nil, false, fmt.Errorf("failed to query tag: %w", err)
+	}
+	return name, nullStringToPtr(desc), true, nil
+}
+
+func saveTagInfo(tx *sql.Tx, tagSlug, name string, description *string) error {
+	_, err := tx.Exec(`
+		INSERT INTO tags (slug, name, description)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (slug) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, updated_at = NOW()
+	`, tagSlug, name, ptrToNullString(description))
+	if err != nil {
+		return fmt.Errorf("failed to save tag: %w", err)
+	}
+	return nil
+}
+
+func addTagCount(tag *models.Tag, kind models.ContentType, count int) {
+	for _, c := range tag.Counts {
+		if c.Type == kind {
+			c.Count += count
+			return
+		}
+	}
+	tag.Counts = append(tag.Counts, &models.TagCount{Type: kind, Count: count})
+}
+
+// preferredTagName picks the most used spelling, breaking ties alphabetically
+func preferredTagName(uses map[string]int) string {
+	best := ""
+	for name, count := range uses {
+		if best == "" || count > uses[best] || (count == uses[best] && name < best) {
+			best = name
+		}
+	}
+	return best
+}
+
+func keys(m map[string]int) []string {
+	list := make([]string, 0, len(m))
+	for k := range m {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+)
+
+// Monologues form threads through parent_id. Published replies always have a published
+// parent: publishing a reply requires it, and unpublishing a monologue unpublishes its
+// replies. Deleting a monologue attaches its replies to its own parent.
+
+// ErrParentNotPublished is returned when publishing a reply before the monologue it
+// replies to
+var ErrParentNotPublished = errors.New("a reply can only be published after the monologue it replies to")
+
+// GetThread returns the published monologue rootID and its published replies as a
+// conversation: every reply follows the monologue it replies to, after the earlier
+// replies to that monologue and their own replies. Returns nil when the root is not
+// published.
+func (db *DB) GetThread(rootID string) ([]*models.Monologue, error) {
+	query := `
+		WITH RECURSIVE thread AS (
+			SELECT id FROM monologues WHERE id = $1 AND is_published = true
+			UNION
+			SELECT r.id FROM monologues r JOIN thread t ON r.parent_id = t.id
+			WHERE r.is_published = true
+		)
+		SELECT ` + monologueColumns + `
+		FROM monologues m JOIN thread t ON t.id = m.id
+		ORDER BY m.published_at, m.created_at
+	`
+
+	monologues, err := db.queryMonologues(query, rootID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query thread: %w", err)
+	}
+
+	var root *models.Monologue
+	replies := map[string][]*models.Monologue{}
+	for _, mono := range monologues {
+		if mono.ID == rootID {
+			root = mono
+		} else if mono.ParentID != nil {
+			replies[*mono.ParentID] = append(replies[*mono.ParentID], mono)
+		}
+	}
+	if root == nil {
+		return nil, nil
+	}
+
+	thread := make([]*models.Monologue, 0, len(monologues))
+	var visit func(mono *models.Monologue)
+	visit = func(mono *models.Monologue) {
+		thread = append(thread, mono)
+		for _, reply := range replies[mono.ID] {
+			visit(reply)
+		}
+	}
+	visit(root)
+
+	return thread, nil
+}
+
+// GetReplies returns the published direct replies to a monologue, oldest first
+func (db *DB) GetReplies(monologueID string) ([]*models.Monologue, error) {
+	query := `
+		SELECT ` + monologueColumns + `
+		FROM monologues m
+		WHERE m.parent_id = $1 AND m.is_published = true
+		ORDER BY m.published_at, m.created_at
+	`
+
+	return db.queryMonologues(query, monologueID)
+}
+
+// checkParentPublished fails when the monologue is a published reply to an unpublished
+// monologue
+func checkParentPublished(tx *sql.Tx, monologueID string) error {
+	var orphaned bool
+	err := tx.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM monologues m JOIN monologues p ON p.id = m.parent_id
+			WHERE m.id = $1 AND m.is_published = true AND p.is_published = false
+		)
+	`, monologueID).Scan(&orphaned)
+	if err != nil {
+		return fmt.Errorf("failed to check parent monologue: %w", err)
+	}
+	if orphaned {
+		return ErrParentNotPublished
+	}
+	return nil
+}
+
+// unpublishReplies unpublishes the replies to a monologue and their replies in turn
+func unpublishReplies(tx *sql.Tx, monologueID string) error {
+	_, err := tx.Exec(`
+		WITH RECURSIVE replies AS (
+			SELECT id FROM monologues WHERE parent_id = $1
+			UNION
+			SELECT r.id FROM monologues r JOIN replies p ON r.parent_id = p.id
+		)
+		UPDATE monologues
+		SET is_published = false, published_at = NULL, updated_at = NOW()
+		WHERE id IN (SELECT id FROM replies) AND is_published = true
+	`, monologueID)
+	if err != nil {
+		return fmt.Errorf("failed to unpublish replies: %w", err)
+	}
+	return nil
+}
+
+// detachReplies attaches the direct replies to a monologue that is about to be deleted to
+// its parent, so the rest of the thread stays connected. Replies to a root become roots.
+func detachReplies(tx *sql.Tx, monologueID string) error {
+	_, err := tx.Exec(`
+		UPDATE monologues
+		SET parent_id = (SELECT parent_id FROM monologues WHERE id = $1), updated_at = NOW()
+		WHERE parent_id = $1
+	`, monologueID)
+	if err != nil {
+		return fmt.Errorf("failed to detach replies: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/naoya0117/portfolio-v2025-api/internal/locale"
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/textutil"
+)
+
+// A blog post is written in its original locale and can have one translation of its
+// title, excerpt, content and SEO fields per other locale. Slug, tags, status and the
+// other fields are shared by all locales.
+
+var (
+	ErrTranslationIsOriginal    = errors.New("the post is written in this locale, update the post instead")
+	ErrOriginalLocaleTranslated = errors.New("the post has a translation in this locale, delete it first")
+)
+
+// translationColumns is the column list scanTranslation reads
+const translationColumns = `locale, title, excerpt, content, seo_title, seo_description,
+			   reading_time, character_count, created_at, updated_at`
+
+// GetBlogPostTranslations returns the translations of a blog post by locale
+func (db *DB) GetBlogPostTranslations(blogPostID string) ([]*models.BlogPostTranslation, error) {
+	rows, err := db.Query(`
+		SELECT `+translationColumns+`
+		FROM blog_post_translations WHERE blog_post_id = $1
+		ORDER BY locale
+	`, blogPostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []*models.BlogPostTranslation{}
+	for rows.Next() {
+		translation, err := scanTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+	return translations, rows.Err()
+}
+
+// GetBlogPostAlternates returns the locales a blog post can be read in with its title in
+// each, the original locale first
+func (db *DB) GetBlogPostAlternates(post *models.BlogPost) ([]*models.BlogPostAlternate, error) {
+	rows, err := db.Query(`
+		SELECT locale, title FROM (
+			SELECT locale, title, 0 AS translated FROM blog_posts WHERE id = $1
+			UNION ALL
+			SELECT locale, title, 1 FROM blog_post_translations WHERE blog_post_id = $1
+		) alternates
+		ORDER BY translated, locale
+	`, post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alternates: %w", err)
+	}
+	defer rows.Close()
+
+	alternates := []*models.BlogPostAlternate{}
+	for rows.Next() {
+		alternate := &models.BlogPostAlternate{Slug: post.Slug}
+		if err := rows.Scan(&alternate.Locale, &alternate.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan alternate: %w", err)
+		}
+		alternates = append(alternates, alternate)
+	}
+	return alternates, rows.Err()
+}
+
+// LocalizeBlogPosts replaces the translatable fields of posts with their translation for
+// the first locale of locale.Fallbacks(requested) the post is available in. Posts that are
+// available in none of them keep their original locale.
+func (db *DB) LocalizeBlogPosts(posts []*models.BlogPost, requested string) error {
+	chain := locale.Fallbacks(requested)
+	if len(posts) == 0 || len(chain) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+
+	rows, err := db.Query(`
+		SELECT blog_post_id, `+translationColumns+`
+		FROM blog_post_translations
+		WHERE blog_post_id = ANY($1::uuid[]) AND locale = ANY($2::text[])
+	`, pq.Array(ids), pq.Array(chain))
+	if err != nil {
+		return fmt.Errorf("failed to query translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := map[string]map[string]*models.BlogPostTranslation{}
+	for rows.Next() {
+		var postID string
+		translation, err := scanTranslation(rows, &postID)
+		if err != nil {
+			return err
+		}
+		if translations[postID] == nil {
+			translations[postID] = map[string]*models.BlogPostTranslation{}
+		}
+		translations[postID][translation.Locale] = translation
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		for _, candidate := range chain {
+			if candidate == post.OriginalLocale {
+				break
+			}
+			if translation := translations[post.ID][candidate]; translation != nil {
+				applyTranslation(post, translation)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// applyTranslation replaces the translatable fields of post with translation
+func applyTranslation(post *models.BlogPost, translation *models.BlogPostTranslation) {
+	post.Locale = translation.Locale
+	post.Title = translation.Title
+	post.Excerpt = translation.Excerpt
+	post.Content = translation.Content
+	post.SeoTitle = translation.SeoTitle
+	post.SeoDescription = translation.SeoDescription
+	post.ReadingTime = translation.ReadingTime
+	post.CharacterCount = translation.CharacterCount
+	post.TranslationUpdatedAt = &translation.UpdatedAt
+}
+
+// SetBlogPostTranslation creates or replaces the translation of a blog post in
+// input.Locale. Returns nil when the post does not exist.
+func (db *DB) SetBlogPostTranslation(blogPostID string, input models.BlogPostTranslationInput) (*models.BlogPostTranslation, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locked so the post cannot move to this locale at the same time
+	var originalLocale string
+	err = tx.QueryRow("SELECT locale FROM blog_posts WHERE id = $1 FOR UPDATE", blogPostID).Scan(&originalLocale)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog post: %w", err)
+	}
+	if originalLocale == input.Locale {
+		return nil, ErrTranslationIsOriginal
+	}
+
+	stats := textutil.Analyze(input.Content)
+	row := tx.QueryRow(`
+		INSERT INTO blog_post_translations (blog_post_id, locale, title, excerpt, content,
+		                                    seo_title, seo_description, reading_time, character_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (blog_post_id, locale) DO UPDATE SET
+			title = EXCLUDED.title, excerpt = EXCLUDED.excerpt, content = EXCLUDED.content,
+			seo_title = EXCLUDED.seo_title, seo_description = EXCLUDED.seo_description,
+			reading_time = EXCLUDED.reading_time, character_count = EXCLUDED.character_count,
+			updated_at = NOW()
+		RETURNING `+translationColumns,
+		blogPostID, input.Locale, input.Title, ptrToNullString(input.Excerpt), input.Content,
+		ptrToNullString(input.SeoTitle), ptrToNullString(input.SeoDescription),
+		stats.ReadingMinutes(), stats.Characters,
+	)
+	translation, err := scanTranslation(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit translation: %w", err)
+	}
+
+	return translation, nil
+}
+
+// DeleteBlogPostTranslation removes the translation of a blog post in the locale tag
+func (db *DB) DeleteBlogPostTranslation(blogPostID, tag string) (bool, error) {
+	result, err := db.Exec(
+		"DELETE FROM blog_post_translations WHERE blog_post_id = $1 AND locale = $2",
+		blogPostID, tag,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete translation: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// checkOriginalLocale fails when a blog post cannot be moved to the locale tag because it
+// has a translation in it. The post is locked until tx ends.
+func checkOriginalLocale(tx *sql.Tx, blogPostID, tag string) error {
+	if _, err := tx.Exec("SELECT id FROM blog_posts WHERE id = $1 FOR UPDATE", blogPostID); err != nil {
+		return fmt.Errorf("failed to lock blog post: %w", err)
+	}
+
+	var translated bool
+	err := tx.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM blog_post_translations WHERE blog_post_id = $1 AND locale = $2)
+	`, blogPostID, tag).Scan(&translated)
+	if err != nil {
+		return fmt.Errorf("failed to check translations: %w", err)
+	}
+	if translated {
+		return ErrOriginalLocaleTranslated
+	}
+	return nil
+}
+
+// scanTranslation reads the translationColumns, preceded by the destinations in prefix
+func scanTranslation(row interface{ Scan(...interface{}) error }, prefix ...interface{}) (*models.BlogPostTranslation, error) {
+	translation := &models.BlogPostTranslation{}
+	var excerpt, seoTitle, seoDescription sql.NullString
+	dest := append(prefix,
+		&translation.Locale, &translation.Title, &excerpt, &translation.Content,
+		&seoTitle, &seoDescription, &translation.ReadingTime, &translation.CharacterCount,
+		&translation.CreatedAt, &translation.UpdatedAt,
+	)
+	if err := row.Scan(dest...); err != nil {
+		return nil, fmt.Errorf("failed to scan translation: %w", err)
+	}
+	translation.Excerpt = nullStringToPtr(excerpt)
+	translation.SeoTitle = nullStringToPtr(seoTitle)
+	translation.SeoDescription = nullStringToPtr(seoDescription)
+	return translation, nil
+}
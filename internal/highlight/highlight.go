@@ -0,0 +1,124 @@
+package highlight
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/gorilla/mux"
+)
+
+// DefaultTheme is used for fenced code in rendered Markdown and when no theme is requested
+const DefaultTheme = "github"
+
+// ErrUnknownTheme is returned for theme names chroma does not ship
+var ErrUnknownTheme = fmt.Errorf("unknown highlight theme")
+
+// Options controls optional parts of the highlighted output
+type Options struct {
+	LineNumbers bool
+	// HighlightLines are 1-based line numbers to mark with the "hl" class
+	HighlightLines []int
+}
+
+// HasTheme reports whether name is a known chroma style
+func HasTheme(name string) bool {
+	_, ok := styles.Registry[name]
+	return ok
+}
+
+// Themes lists the available theme names in alphabetical order
+func Themes() []string {
+	names := make([]string, 0, len(styles.Registry))
+	for name := range styles.Registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// classPrefix namespaces every CSS class by theme ("github-chroma", "github-k", ...) so
+// stylesheets for several themes can be loaded on the same page
+func classPrefix(theme string) string {
+	return theme + "-"
+}
+
+// HTML highlights code as class-based HTML for theme. The language is resolved by name,
+// alias or file extension, falling back to content analysis and then plain text.
+func HTML(code, language, theme string, opts Options) (string, error) {
+	if theme == "" {
+		theme = DefaultTheme
+	}
+	if !HasTheme(theme) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownTheme, theme)
+	}
+
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		lexer = lexers.Analyse(code)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return "", fmt.Errorf("failed to tokenise code: %w", err)
+	}
+
+	formatterOptions := []chromahtml.Option{
+		chromahtml.WithClasses(true),
+		chromahtml.ClassPrefix(classPrefix(theme)),
+		chromahtml.WithLineNumbers(opts.LineNumbers),
+	}
+	if len(opts.HighlightLines) > 0 {
+		ranges := make([][2]int, len(opts.HighlightLines))
+		for i, line := range opts.HighlightLines {
+			ranges[i] = [2]int{line, line}
+		}
+		formatterOptions = append(formatterOptions, chromahtml.HighlightLines(ranges))
+	}
+
+	var sb strings.Builder
+	formatter := chromahtml.New(formatterOptions...)
+	if err := formatter.Format(&sb, styles.Get(theme), iterator); err != nil {
+		return "", fmt.Errorf("failed to format code: %w", err)
+	}
+
+	return sb.String(), nil
+}
+
+// WriteCSS writes the stylesheet matching the classes HTML produces for theme
+func WriteCSS(w io.Writer, theme string) error {
+	if !HasTheme(theme) {
+		return fmt.Errorf("%w: %s", ErrUnknownTheme, theme)
+	}
+
+	formatter := chromahtml.New(
+		chromahtml.WithClasses(true),
+		chromahtml.ClassPrefix(classPrefix(theme)),
+	)
+	return formatter.WriteCSS(w, styles.Get(theme))
+}
+
+// CSSHandler serves the stylesheet for the theme in the {theme} route variable
+func CSSHandler(w http.ResponseWriter, r *http.Request) {
+	theme := mux.Vars(r)["theme"]
+	if !HasTheme(theme) {
+		http.Error(w, "Unknown theme", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	if err := WriteCSS(w, theme); err != nil {
+		fmt.Printf("[HIGHLIGHT] Failed to write CSS for theme %s: %v\n", theme, err)
+	}
+}
@@ -0,0 +1,37 @@
+package locale
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Default is the locale of blog posts written before locales existed
+const Default = "ja"
+
+// Normalize returns the canonical form of a BCP 47 language tag, e.g. "en-US" for
+// "EN_us"
+func Normalize(tag string) (string, error) {
+	parsed, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if err != nil || parsed == language.Und {
+		return "", fmt.Errorf("%q is not a valid language tag", tag)
+	}
+	return parsed.String(), nil
+}
+
+// Fallbacks returns tag followed by the tags it falls back to, dropping one subtag at a
+// time: "zh-Hant-TW", "zh-Hant", "zh". Invalid tags have no fallbacks.
+func Fallbacks(tag string) []string {
+	normalized, err := Normalize(tag)
+	if err != nil {
+		return nil
+	}
+
+	chain := []string{normalized}
+	for i := strings.LastIndexByte(normalized, '-'); i > 0; i = strings.LastIndexByte(normalized, '-') {
+		normalized = normalized[:i]
+		chain = append(chain, normalized)
+	}
+	return chain
+}
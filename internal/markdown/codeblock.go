@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/highlight"
+)
+
+// codeBlockRenderer highlights fenced code blocks that declare a language with the
+// default theme. Blocks without a language keep goldmark's plain <pre><code> output.
+type codeBlockRenderer struct {
+	html.Config
+}
+
+func newCodeBlockRenderer() renderer.NodeRenderer {
+	return &codeBlockRenderer{Config: html.NewConfig()}
+}
+
+func (r *codeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r *codeBlockRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	block := node.(*ast.FencedCodeBlock)
+	language := string(block.Language(source))
+
+	var code []byte
+	lines := block.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		code = append(code, line.Value(source)...)
+	}
+
+	if language != "" {
+		highlighted, err := highlight.HTML(string(code), language, highlight.DefaultTheme, highlight.Options{})
+		if err == nil {
+			_, _ = w.WriteString(highlighted)
+			return ast.WalkSkipChildren, nil
+		}
+	}
+
+	_, _ = w.WriteString("<pre><code")
+	if language != "" {
+		_, _ = w.WriteString(` class="language-`)
+		r.Writer.Write(w, []byte(language))
+		_, _ = w.WriteString(`"`)
+	}
+	_, _ = w.WriteString(">")
+	r.Writer.RawWrite(w, code)
+	_, _ = w.WriteString("</code></pre>\n")
+
+	return ast.WalkSkipChildren, nil
+}
@@ -0,0 +1,192 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+)
+
+// cacheSize is the number of rendered documents kept in memory
+const cacheSize = 512
+
+// Rendered is the sanitized HTML for a Markdown document and its table of contents
+type Rendered struct {
+	HTML string
+	Toc  []*models.TocEntry
+}
+
+// Renderer converts GitHub-flavored Markdown to sanitized HTML and caches the result
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+	cache  *lru.Cache[string, *Rendered]
+}
+
+func NewRenderer() *Renderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		// Raw HTML is passed through and cleaned by the sanitizer below
+		goldmark.WithRendererOptions(
+			html.WithUnsafe(),
+			renderer.WithNodeRenderers(util.Prioritized(newCodeBlockRenderer(), 100)),
+		),
+	)
+
+	cache, _ := lru.New[string, *Rendered](cacheSize)
+
+	return &Renderer{
+		md:     md,
+		policy: newPolicy(),
+		cache:  cache,
+	}
+}
+
+// newPolicy allows user-generated content plus the attributes the renderer relies on:
+// heading anchors, code language and highlighting classes and GFM task list checkboxes
+func newPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("id").OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^[\w-]+( [\w-]+)*$`)).OnElements("pre", "span")
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	return policy
+}
+
+// CacheKey identifies one version of a document. Changing updatedAt invalidates the
+// cached rendering.
+func CacheKey(kind, id string, updatedAt time.Time) string {
+	return fmt.Sprintf("%s:%s:%d", kind, id, updatedAt.UnixNano())
+}
+
+// Render returns the rendered document for key, rendering source on a cache miss
+func (r *Renderer) Render(key, source string) (*Rendered, error) {
+	if rendered, ok := r.cache.Get(key); ok {
+		return rendered, nil
+	}
+
+	rendered, err := r.render([]byte(source))
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Add(key, rendered)
+	return rendered, nil
+}
+
+func (r *Renderer) render(source []byte) (*Rendered, error) {
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	doc := r.md.Parser().Parse(text.NewReader(source), parser.WithContext(ctx))
+
+	toc := make([]*models.TocEntry, 0)
+	err := ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		anchor := ""
+		if id, ok := heading.AttributeString("id"); ok {
+			if b, ok := id.([]byte); ok {
+				anchor = string(b)
+			}
+		}
+
+		toc = append(toc, &models.TocEntry{
+			Level:  heading.Level,
+			Text:   strings.TrimSpace(nodeText(heading, source)),
+			Anchor: anchor,
+		})
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, source, doc); err != nil {
+		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return &Rendered{
+		HTML: r.policy.Sanitize(buf.String()),
+		Toc:  toc,
+	}, nil
+}
+
+// nodeText concatenates the plain text below node, ignoring markup
+func nodeText(node ast.Node, source []byte) string {
+	var sb strings.Builder
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.Text:
+			sb.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return sb.String()
+}
+
+// headingIDs generates GitHub-style anchors. Unlike goldmark's default it keeps non-ASCII
+// letters, so Japanese headings get readable anchors instead of "heading-1", "heading-2".
+type headingIDs struct {
+	used map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{used: make(map[string]bool)}
+}
+
+func (ids *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(string(value))) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-':
+			sb.WriteRune(r)
+		case unicode.IsSpace(r):
+			sb.WriteByte('-')
+		}
+	}
+
+	base := sb.String()
+	if base == "" {
+		base = "section"
+	}
+
+	id := base
+	for i := 1; ids.used[id]; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	ids.used[id] = true
+
+	return []byte(id)
+}
+
+func (ids *headingIDs) Put(value []byte) {
+	ids.used[string(value)] = true
+}
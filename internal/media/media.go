@@ -0,0 +1,403 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/buckket/go-blurhash"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/outbound"
+	"github.com/naoya0117/portfolio-v2025-api/internal/storage"
+)
+
+// Limits and sizes used by NewProcessor
+const (
+	DefaultMaxSize = 10 << 20 // 10 MiB
+	// MaxPixels rejects images whose decoded size would exhaust memory
+	MaxPixels = 50_000_000
+	// ThumbnailSize is the longest side of the thumbnail variant
+	ThumbnailSize = 480
+	jpegQuality   = 85
+	// placeholderSize is the longest side of the copy placeholders are computed from
+	placeholderSize = 64
+	// remoteTimeout bounds downloading a linked image
+	remoteTimeout = 10 * time.Second
+)
+
+// Variant names
+const (
+	VariantThumbnail     = "thumbnail"
+	VariantWebP          = "webp"
+	VariantThumbnailWebP = "thumbnail-webp"
+)
+
+var (
+	ErrTooLarge        = errors.New("file is too large")
+	ErrUnsupportedType = errors.New("unsupported file type")
+	ErrTooManyPixels   = errors.New("image dimensions are too large")
+)
+
+// extensions lists the accepted content types, detected from the file's bytes rather
+// than trusted from the client. SVG is deliberately not accepted since it can carry scripts.
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Processor validates uploads, strips their metadata, generates variants and writes
+// everything to Storage. Client downloads images that are linked instead of uploaded.
+type Processor struct {
+	Storage storage.Storage
+	MaxSize int64
+	Client  *http.Client
+}
+
+// NewProcessor returns a processor whose client refuses private and internal addresses,
+// since linked image URLs come from API callers
+func NewProcessor(store storage.Storage) *Processor {
+	return &Processor{
+		Storage: store,
+		MaxSize: DefaultMaxSize,
+		Client:  outbound.NewClient(outbound.Options{Timeout: remoteTimeout}),
+	}
+}
+
+// URL returns the public URL of a stored key
+func (p *Processor) URL(key string) string {
+	return p.Storage.URL(key)
+}
+
+// Process stores the upload read from r and returns the unsaved media record
+func (p *Processor) Process(ctx context.Context, filename string, r io.Reader) (*models.Media, error) {
+	data, err := io.ReadAll(io.LimitReader(r, p.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if int64(len(data)) > p.MaxSize {
+		return nil, fmt.Errorf("%w: the limit is %d MiB", ErrTooLarge, p.MaxSize>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := extensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, config.Width, config.Height)
+	}
+
+	cleaned, img, err := clean(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if img == nil {
+		if img, _, err = image.Decode(bytes.NewReader(cleaned)); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+		}
+	}
+
+	base := path.Join(time.Now().Format("2006/01"), randomID())
+	bounds := img.Bounds()
+	media := &models.Media{
+		Filename:    sanitizeFilename(filename),
+		ContentType: contentType,
+		Size:        len(cleaned),
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+		StorageKey:  base + ext,
+	}
+
+	media.Blurhash, media.DominantColor = placeholders(img)
+
+	files := map[string][]byte{media.StorageKey: cleaned}
+	contentTypes := map[string]string{media.StorageKey: contentType}
+
+	thumbnail := img
+	if bounds.Dx() > ThumbnailSize || bounds.Dy() > ThumbnailSize {
+		thumbnail = resize(img, ThumbnailSize)
+		thumbData, thumbType, thumbExt, err := encodeThumbnail(thumbnail)
+		if err != nil {
+			return nil, err
+		}
+		variant := newVariant(VariantThumbnail, base+"-thumb"+thumbExt, thumbType, thumbnail, len(thumbData))
+		media.Variants = append(media.Variants, variant)
+		files[variant.StorageKey] = thumbData
+		contentTypes[variant.StorageKey] = thumbType
+	}
+
+	// Animated GIFs would lose their animation, and WebP uploads need no WebP copy
+	if contentType != "image/webp" && !isAnimatedGIF(cleaned, contentType) {
+		webps := []struct {
+			name, key string
+			img       image.Image
+			size      int
+		}{
+			{VariantWebP, base + ".webp", img, len(cleaned)},
+		}
+		if len(media.Variants) > 0 {
+			webps = append(webps, struct {
+				name, key string
+				img       image.Image
+				size      int
+			}{VariantThumbnailWebP, base + "-thumb.webp", thumbnail, media.Variants[0].Size})
+		}
+
+		for _, w := range webps {
+			var buf bytes.Buffer
+			if err := nativewebp.Encode(&buf, w.img, nil); err != nil {
+				return nil, fmt.Errorf("failed to encode webp: %w", err)
+			}
+			// The encoder is lossless, so photos can come out larger than the JPEG they
+			// were made from. Such variants would not help anyone and are skipped.
+			if buf.Len() >= w.size {
+				continue
+			}
+			variant := newVariant(w.name, w.key, "image/webp", w.img, buf.Len())
+			media.Variants = append(media.Variants, variant)
+			files[variant.StorageKey] = buf.Bytes()
+			contentTypes[variant.StorageKey] = "image/webp"
+		}
+	}
+
+	written := make([]string, 0, len(files))
+	for key, body := range files {
+		if err := p.Storage.Put(ctx, key, bytes.NewReader(body), contentTypes[key]); err != nil {
+			p.deleteKeys(ctx, written)
+			return nil, err
+		}
+		written = append(written, key)
+	}
+
+	return media, nil
+}
+
+// Remove deletes the stored files of media and its variants
+func (p *Processor) Remove(ctx context.Context, media *models.Media) error {
+	keys := []string{media.StorageKey}
+	for _, variant := range media.Variants {
+		keys = append(keys, variant.StorageKey)
+	}
+	return p.deleteKeys(ctx, keys)
+}
+
+func (p *Processor) deleteKeys(ctx context.Context, keys []string) error {
+	var firstErr error
+	for _, key := range keys {
+		if err := p.Storage.Delete(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// clean strips metadata from data. JPEGs with a non-default EXIF orientation are
+// rotated and re-encoded, in which case the decoded image is returned as well.
+func clean(data []byte, contentType string) ([]byte, image.Image, error) {
+	switch contentType {
+	case "image/jpeg":
+		stripped, orientation, err := stripJPEG(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+		}
+		if orientation <= 1 {
+			return stripped, nil, nil
+		}
+
+		img, err := jpeg.Decode(bytes.NewReader(stripped))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+		}
+		img = applyOrientation(img, orientation)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 92}); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode jpeg: %w", err)
+		}
+		return buf.Bytes(), img, nil
+	case "image/png":
+		stripped, err := stripPNG(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+		}
+		return stripped, nil, nil
+	case "image/webp":
+		stripped, err := stripWebP(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+		}
+		return stripped, nil, nil
+	default:
+		// GIF has no standard metadata block
+		return data, nil, nil
+	}
+}
+
+// resize scales img to fit in a maxSide x maxSide square, keeping the aspect ratio
+func resize(img image.Image, maxSide int) image.Image {
+	b := img.Bounds()
+	w, h := maxSide, b.Dy()*maxSide/b.Dx()
+	if b.Dy() > b.Dx() {
+		w, h = b.Dx()*maxSide/b.Dy(), maxSide
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, max(w, 1), max(h, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// encodeThumbnail uses JPEG for opaque images and PNG where transparency must be kept
+func encodeThumbnail(img image.Image) ([]byte, string, string, error) {
+	var buf bytes.Buffer
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", "", fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		return buf.Bytes(), "image/png", ".png", nil
+	}
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, "", "", fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), "image/jpeg", ".jpg", nil
+}
+
+// placeholders returns the blurhash and dominant color shown while img loads. They are
+// computed from a small copy; the result is the same and much faster.
+func placeholders(img image.Image) (*string, *string) {
+	small := img
+	if bounds := img.Bounds(); bounds.Dx() > placeholderSize || bounds.Dy() > placeholderSize {
+		small = resize(img, placeholderSize)
+	}
+
+	var hash *string
+	if encoded, err := blurhash.Encode(4, 3, small); err == nil {
+		hash = &encoded
+	}
+	color := dominantColor(small)
+	return hash, &color
+}
+
+// dominantColor returns the average color of img as "#rrggbb", ignoring transparent pixels
+func dominantColor(img image.Image) string {
+	var r, g, b, n uint64
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pr, pg, pb, pa := img.At(x, y).RGBA()
+			if pa == 0 {
+				continue
+			}
+			// Un-premultiply so half-transparent pixels count with their real color
+			r += uint64(pr) * 0xffff / uint64(pa)
+			g += uint64(pg) * 0xffff / uint64(pa)
+			b += uint64(pb) * 0xffff / uint64(pa)
+			n++
+		}
+	}
+	if n == 0 {
+		return "#000000"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r/n>>8, g/n>>8, b/n>>8)
+}
+
+func isAnimatedGIF(data []byte, contentType string) bool {
+	if contentType != "image/gif" || len(data) < 13 {
+		return false
+	}
+
+	// Header and logical screen descriptor, then the optional global color table
+	i := 13
+	if packed := data[10]; packed&0x80 != 0 {
+		i += 3 << (packed&0x07 + 1)
+	}
+
+	frames := 0
+	for i < len(data) {
+		switch data[i] {
+		case 0x21: // extension: label, then data sub-blocks
+			i = skipGIFSubBlocks(data, i+2)
+		case 0x2C: // image descriptor, optional local color table, then image data
+			if frames++; frames > 1 {
+				return true
+			}
+			if i+10 > len(data) {
+				return false
+			}
+			packed := data[i+9]
+			i += 10
+			if packed&0x80 != 0 {
+				i += 3 << (packed&0x07 + 1)
+			}
+			// Skip the LZW minimum code size
+			i = skipGIFSubBlocks(data, i+1)
+		default: // trailer
+			return false
+		}
+	}
+	return false
+}
+
+// skipGIFSubBlocks returns the index after the sub-blocks starting at i, which end with
+// an empty block
+func skipGIFSubBlocks(data []byte, i int) int {
+	for i < len(data) {
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i
+		}
+		i += size
+	}
+	return len(data)
+}
+
+func newVariant(name, key, contentType string, img image.Image, size int) *models.MediaVariant {
+	return &models.MediaVariant{
+		Name:        name,
+		ContentType: contentType,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Size:        size,
+		StorageKey:  key,
+	}
+}
+
+// sanitizeFilename keeps the base name of the client's file name for display only
+func sanitizeFilename(filename string) string {
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "." || name == "/" {
+		return "upload"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+	return name
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,235 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+)
+
+var errMalformed = errors.New("malformed image file")
+
+// stripJPEG removes the APP1 (EXIF, XMP), APP13 (IPTC) and comment segments, which can
+// contain GPS coordinates and camera details, without re-encoding the image. The EXIF
+// orientation found on the way is returned so the caller can apply it to the pixels.
+func stripJPEG(data []byte) ([]byte, int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, 0, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+	orientation := 1
+
+	for i := 2; i < len(data); {
+		if data[i] != 0xFF || i+1 >= len(data) {
+			return nil, 0, errMalformed
+		}
+		marker := data[i+1]
+
+		// Standalone markers have no length
+		if marker == 0xD8 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out.Write(data[i : i+2])
+			i += 2
+			continue
+		}
+		if marker == 0xFF {
+			i++ // fill byte
+			continue
+		}
+		if i+4 > len(data) {
+			return nil, 0, errMalformed
+		}
+
+		// The length counts its own two bytes
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 {
+			return nil, 0, errMalformed
+		}
+		end := i + 2 + length
+		if end > len(data) {
+			return nil, 0, errMalformed
+		}
+		segment := data[i:end]
+
+		switch marker {
+		case 0xE1:
+			if o := exifOrientation(segment[4:]); o != 0 {
+				orientation = o
+			}
+		case 0xED, 0xFE:
+		case 0xDA:
+			// Start of scan: the compressed data and everything after it is kept as is
+			out.Write(data[i:])
+			return out.Bytes(), orientation, nil
+		default:
+			out.Write(segment)
+		}
+		i = end
+	}
+
+	return out.Bytes(), orientation, nil
+}
+
+// exifOrientation reads tag 0x0112 from IFD0 of an APP1 payload, returning 0 if absent
+func exifOrientation(payload []byte) int {
+	if !bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+		return 0
+	}
+	tiff := payload[6:]
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// pngMetadataChunks are ancillary chunks holding EXIF data, free text or timestamps
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true,
+}
+
+// stripPNG removes metadata chunks, keeping image data and color information
+func stripPNG(data []byte) ([]byte, error) {
+	const signatureLen = 8
+	if len(data) < signatureLen {
+		return nil, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:signatureLen])
+
+	for i := signatureLen; i < len(data); {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) || end < i {
+			return nil, errMalformed
+		}
+		if !pngMetadataChunks[string(data[i+4:i+8])] {
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+
+	return out.Bytes(), nil
+}
+
+// VP8X feature flags for the metadata chunks
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// stripWebP removes the EXIF and XMP chunks of an extended WebP file and clears their flags
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errMalformed
+	}
+
+	var body bytes.Buffer
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if end > len(data) {
+			// Some encoders omit the padding byte of the last chunk
+			if i+8+size > len(data) {
+				return nil, errMalformed
+			}
+			end = len(data)
+		}
+
+		switch fourCC := string(data[i : i+4]); fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			chunk[8] &^= webpFlagEXIF | webpFlagXMP
+			body.Write(chunk)
+		default:
+			body.Write(data[i:end])
+		}
+		i = end
+	}
+
+	out := make([]byte, 12, 12+body.Len())
+	copy(out, "RIFF")
+	binary.LittleEndian.PutUint32(out[4:], uint32(4+body.Len()))
+	copy(out[8:], "WEBP")
+	return append(out, body.Bytes()...), nil
+}
+
+// applyOrientation returns img transformed as EXIF orientation o describes, so it
+// displays upright without the tag
+func applyOrientation(img image.Image, o int) image.Image {
+	if o <= 1 || o > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	src := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch o {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+
+	return dst
+}
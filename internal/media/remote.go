@@ -0,0 +1,77 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+)
+
+// ErrRemoteImage is returned when a linked image cannot be downloaded
+var ErrRemoteImage = errors.New("image could not be downloaded")
+
+// RemoteImage describes an image that is linked by URL rather than uploaded
+type RemoteImage struct {
+	Width         int
+	Height        int
+	Blurhash      *string
+	DominantColor *string
+}
+
+// Inspect downloads the image at rawURL and returns its dimensions and placeholders. The
+// same size, type and pixel limits as for uploads apply; nothing is stored.
+func (p *Processor) Inspect(ctx context.Context, rawURL string) (*RemoteImage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRemoteImage, err)
+	}
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRemoteImage, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteImage, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, p.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRemoteImage, err)
+	}
+	if int64(len(data)) > p.MaxSize {
+		return nil, fmt.Errorf("%w: the limit is %d MiB", ErrTooLarge, p.MaxSize>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := extensions[contentType]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, config.Width, config.Height)
+	}
+
+	// clean applies the EXIF orientation, so the dimensions are those the image is shown in
+	cleaned, img, err := clean(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if img == nil {
+		if img, _, err = image.Decode(bytes.NewReader(cleaned)); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+		}
+	}
+
+	remote := &RemoteImage{Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+	remote.Blurhash, remote.DominantColor = placeholders(img)
+	return remote, nil
+}
@@ -19,41 +19,120 @@ type Skill struct {
 }
 
 type BlogPost struct {
-	ID              string     `json:"id"`
-	Title           string     `json:"title"`
-	Slug            string     `json:"slug"`
-	Excerpt         *string    `json:"excerpt"`
-	Content         string     `json:"content"`
-	CoverImageURL   *string    `json:"coverImageUrl"`
-	Tags            []string   `json:"tags"`
-	Status          BlogStatus `json:"status"`
-	SeoTitle        *string    `json:"seoTitle"`
-	SeoDescription  *string    `json:"seoDescription"`
-	PublishedAt     *string    `json:"publishedAt"`
-	LikeCount       *int       `json:"likeCount"`
-	CreatedAt       time.Time  `json:"createdAt"`
-	UpdatedAt       time.Time  `json:"updatedAt"`
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Slug           string     `json:"slug"`
+	Excerpt        *string    `json:"excerpt"`
+	Content        string     `json:"content"`
+	CoverImageURL  *string    `json:"coverImageUrl"`
+	Tags           []string   `json:"tags"`
+	Status         BlogStatus `json:"status"`
+	SeoTitle       *string    `json:"seoTitle"`
+	SeoDescription *string    `json:"seoDescription"`
+	PublishedAt    *string    `json:"publishedAt"`
+	LikeCount      *int       `json:"likeCount"`
+	ReadingTime    int        `json:"readingTime"`
+	CharacterCount int        `json:"characterCount"`
+	ScheduledAt    *string    `json:"scheduledAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	// RedirectTo is set when the post was looked up by one of its previous slugs
+	RedirectTo *string `json:"redirectTo"`
+	// Locale is the locale of the translatable fields, OriginalLocale the one the post
+	// was written in
+	Locale         string `json:"locale"`
+	OriginalLocale string `json:"originalLocale"`
+	// TranslationUpdatedAt is when the translation in Locale was last changed, nil for
+	// posts in their original locale
+	TranslationUpdatedAt *time.Time `json:"-"`
 }
 
 type Monologue struct {
-	ID               string          `json:"id"`
-	Content          string          `json:"content"`
-	ContentType      ContentType     `json:"contentType"`
-	CodeLanguage     *string         `json:"codeLanguage"`
-	CodeSnippet      *string         `json:"codeSnippet"`
-	Tags             []string        `json:"tags"`
-	IsPublished      bool            `json:"isPublished"`
-	PublishedAt      *string         `json:"publishedAt"`
-	CreatedAt        time.Time       `json:"createdAt"`
-	UpdatedAt        time.Time       `json:"updatedAt"`
-	URL              *string         `json:"url"`
-	URLPreview       *URLPreview     `json:"urlPreview"`
-	RelatedBlogPosts []string        `json:"relatedBlogPosts"`
-	Series           *string         `json:"series"`
-	Category         *string         `json:"category"`
-	LikeCount        *int            `json:"likeCount"`
+	ID             string            `json:"id"`
+	Content        string            `json:"content"`
+	ContentType    ContentType       `json:"contentType"`
+	CodeLanguage   *string           `json:"codeLanguage"`
+	CodeSnippet    *string           `json:"codeSnippet"`
+	Tags           []string          `json:"tags"`
+	IsPublished    bool              `json:"isPublished"`
+	PublishedAt    *string           `json:"publishedAt"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+	URL            *string           `json:"url"`
+	URLPreview     *URLPreview       `json:"urlPreview"`
+	Images         []*MonologueImage `json:"images"`
+	Series         *string           `json:"series"`
+	Category       *string           `json:"category"`
+	CodeCategoryID *string           `json:"codeCategoryId"`
+	Difficulty     *Difficulty       `json:"difficulty"`
+	ParentID       *string           `json:"parentId"`
+	ReplyCount     int               `json:"replyCount"`
+	LikeCount      *int              `json:"likeCount"`
+	ReadingTime    int               `json:"readingTime"`
+	CharacterCount int               `json:"characterCount"`
+	ScheduledAt    *string           `json:"scheduledAt"`
 }
 
+// MonologueImage is one image of an IMAGE monologue, in display order. MediaID is set
+// when the image is an upload from the media library.
+type MonologueImage struct {
+	MediaID       *string `json:"mediaId"`
+	URL           string  `json:"url"`
+	Alt           *string `json:"alt"`
+	Width         *int    `json:"width"`
+	Height        *int    `json:"height"`
+	Blurhash      *string `json:"blurhash"`
+	DominantColor *string `json:"dominantColor"`
+	Caption       *string `json:"caption"`
+}
+
+// ScheduledContent is a blog post or monologue waiting to be published at ScheduledAt.
+// Exactly one of BlogPost and Monologue is set.
+type ScheduledContent struct {
+	ID          string      `json:"id"`
+	Type        ContentType `json:"type"`
+	Title       string      `json:"title"`
+	ScheduledAt string      `json:"scheduledAt"`
+	BlogPost    *BlogPost   `json:"blogPost"`
+	Monologue   *Monologue  `json:"monologue"`
+}
+
+// Media is an uploaded image. StorageKey locates the file in the storage backend.
+type Media struct {
+	ID          string  `json:"id"`
+	Filename    string  `json:"filename"`
+	ContentType string  `json:"contentType"`
+	Size        int     `json:"size"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	Alt         *string `json:"alt"`
+	// Blurhash and DominantColor are placeholders shown while the image loads
+	Blurhash      *string         `json:"blurhash"`
+	DominantColor *string         `json:"dominantColor"`
+	Variants      []*MediaVariant `json:"variants"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	StorageKey    string          `json:"-"`
+}
+
+func (Media) IsNode()         {}
+func (m Media) GetID() string { return m.ID }
+
+type MediaVariant struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	Size        int    `json:"size"`
+	StorageKey  string `json:"-"`
+}
+
+// MediaUsage is a place where a media file's URL is referenced
+type MediaUsage struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Field string `json:"field"`
+}
 
 type URLPreview struct {
 	Title       string    `json:"title"`
@@ -76,7 +155,6 @@ const (
 	ContentTypeBlog       ContentType = "BLOG"
 )
 
-
 type BlogStatus string
 
 const (
@@ -85,18 +163,48 @@ const (
 	BlogStatusArchived  BlogStatus = "ARCHIVED"
 )
 
+type Difficulty string
+
+const (
+	DifficultyBeginner     Difficulty = "BEGINNER"
+	DifficultyIntermediate Difficulty = "INTERMEDIATE"
+	DifficultyAdvanced     Difficulty = "ADVANCED"
+)
+
+// CodeCategory is a node of the category tree monologues are filed under. Count is the
+// number of published monologues in the category itself, TotalCount includes those of
+// all descendants.
+type CodeCategory struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Slug        string          `json:"slug"`
+	Description *string         `json:"description"`
+	ParentID    *string         `json:"parentId"`
+	Color       *string         `json:"color"`
+	Icon        *string         `json:"icon"`
+	Children    []*CodeCategory `json:"children"`
+	Count       int             `json:"count"`
+	TotalCount  int             `json:"totalCount"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+func (CodeCategory) IsNode()         {}
+func (c CodeCategory) GetID() string { return c.ID }
+
 type Profile struct {
-	ID          string         `json:"id"`
-	Name        string         `json:"name"`
-	Title       *string        `json:"title"`
-	Bio         *string        `json:"bio"`
-	AvatarURL   *string        `json:"avatarUrl"`
-	SocialLinks []*SocialLink  `json:"socialLinks"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Title       *string       `json:"title"`
+	Bio         *string       `json:"bio"`
+	AvatarURL   *string       `json:"avatarUrl"`
+	SocialLinks []*SocialLink `json:"socialLinks"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
 }
 
 type SocialLink struct {
+	ID       string  `json:"id"`
 	Platform string  `json:"platform"`
 	URL      string  `json:"url"`
 	Icon     *string `json:"icon"`
@@ -136,17 +244,123 @@ type RelatedContent struct {
 	ReadTime    *int        `json:"readTime"`
 }
 
+type TocEntry struct {
+	Level  int    `json:"level"`
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
+}
+
+type BulkOperationResult struct {
+	Results      []*BulkItemResult `json:"results"`
+	SuccessCount int               `json:"successCount"`
+	FailureCount int               `json:"failureCount"`
+}
+
+type BulkItemResult struct {
+	ID      string  `json:"id"`
+	Success bool    `json:"success"`
+	Changed bool    `json:"changed"`
+	Error   *string `json:"error"`
+}
+
+// ContentArchive groups published content by month. Items are only filled in for the
+// requested year or month.
+type ContentArchive struct {
+	Years       []*ArchiveYear `json:"years"`
+	Items       []*ArchiveItem `json:"items"`
+	TotalCount  int            `json:"totalCount"`
+	HasNextPage bool           `json:"hasNextPage"`
+}
+
+type ArchiveYear struct {
+	Year   int             `json:"year"`
+	Count  int             `json:"count"`
+	Months []*ArchiveMonth `json:"months"`
+}
+
+type ArchiveMonth struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Count int `json:"count"`
+}
+
+// ArchiveItem is a blog post or monologue in the archive. Exactly one of BlogPost and
+// Monologue is set.
+type ArchiveItem struct {
+	ID          string      `json:"id"`
+	Type        ContentType `json:"type"`
+	Title       string      `json:"title"`
+	PublishedAt string      `json:"publishedAt"`
+	BlogPost    *BlogPost   `json:"blogPost"`
+	Monologue   *Monologue  `json:"monologue"`
+}
+
+// Series is an ordered collection of blog posts and monologues
+type Series struct {
+	ID          string    `json:"id"`
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (Series) IsNode()         {}
+func (s Series) GetID() string { return s.ID }
+
+// SeriesItem is a blog post or monologue at a 1-based Position of its series. Exactly one
+// of BlogPost and Monologue is set.
+type SeriesItem struct {
+	ID          string      `json:"id"`
+	Type        ContentType `json:"type"`
+	Title       string      `json:"title"`
+	Position    int         `json:"position"`
+	PublishedAt *string     `json:"publishedAt"`
+	BlogPost    *BlogPost   `json:"blogPost"`
+	Monologue   *Monologue  `json:"monologue"`
+}
+
+// SeriesNavigation locates a blog post or monologue among the published items of its series
+type SeriesNavigation struct {
+	Series   *Series     `json:"series"`
+	Position int         `json:"position"`
+	Total    int         `json:"total"`
+	Previous *SeriesItem `json:"previous"`
+	Next     *SeriesItem `json:"next"`
+}
+
+// Tag groups the spellings of a tag used in content (Variants) under one slug, with the
+// name and description managed by admins
+type Tag struct {
+	Slug        string      `json:"slug"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	Count       int         `json:"count"`
+	Counts      []*TagCount `json:"counts"`
+	Variants    []string    `json:"-"`
+}
+
+// TagCount is the number of published items of one content type using a tag
+type TagCount struct {
+	Type  ContentType `json:"type"`
+	Count int         `json:"count"`
+}
+
+// PreviewToken grants read access to one unpublished blog post or monologue until ExpiresAt
+type PreviewToken struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
 type LikeResponse struct {
 	ID        string `json:"id"`
 	LikeCount int    `json:"likeCount"`
 	IsLiked   bool   `json:"isLiked"`
 }
 
-
-
 type CreateBlogPostInput struct {
 	Title          string      `json:"title"`
-	Slug           string      `json:"slug"`
+	Slug           *string     `json:"slug"`
 	Excerpt        *string     `json:"excerpt"`
 	Content        string      `json:"content"`
 	CoverImageURL  *string     `json:"coverImageUrl"`
@@ -154,6 +368,7 @@ type CreateBlogPostInput struct {
 	Status         *BlogStatus `json:"status"`
 	SeoTitle       *string     `json:"seoTitle"`
 	SeoDescription *string     `json:"seoDescription"`
+	Locale         *string     `json:"locale"`
 }
 
 type UpdateBlogPostInput struct {
@@ -166,32 +381,194 @@ type UpdateBlogPostInput struct {
 	Status         *BlogStatus `json:"status"`
 	SeoTitle       *string     `json:"seoTitle"`
 	SeoDescription *string     `json:"seoDescription"`
+	Locale         *string     `json:"locale"`
+}
+
+// BlogPostTranslation is a blog post's title, excerpt, content and SEO fields in another
+// locale than the one it was written in
+type BlogPostTranslation struct {
+	Locale         string    `json:"locale"`
+	Title          string    `json:"title"`
+	Excerpt        *string   `json:"excerpt"`
+	Content        string    `json:"content"`
+	SeoTitle       *string   `json:"seoTitle"`
+	SeoDescription *string   `json:"seoDescription"`
+	ReadingTime    int       `json:"readingTime"`
+	CharacterCount int       `json:"characterCount"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type BlogPostTranslationInput struct {
+	Locale         string  `json:"locale"`
+	Title          string  `json:"title"`
+	Excerpt        *string `json:"excerpt"`
+	Content        string  `json:"content"`
+	SeoTitle       *string `json:"seoTitle"`
+	SeoDescription *string `json:"seoDescription"`
+}
+
+// BlogPostAlternate is a locale a blog post can be read in, for hreflang links
+type BlogPostAlternate struct {
+	Locale string `json:"locale"`
+	Slug   string `json:"slug"`
+	Title  string `json:"title"`
 }
 
 type CreateMonologueInput struct {
-	Content        string       `json:"content"`
-	ContentType    ContentType  `json:"contentType"`
-	CodeLanguage   *string      `json:"codeLanguage"`
-	CodeSnippet    *string      `json:"codeSnippet"`
-	Tags           []string     `json:"tags"`
-	IsPublished    *bool        `json:"isPublished"`
-	URL            *string      `json:"url"`
-	Series         *string      `json:"series"`
-	Category       *string      `json:"category"`
+	ParentID           *string                `json:"parentId"`
+	Content            string                 `json:"content"`
+	ContentType        ContentType            `json:"contentType"`
+	CodeLanguage       *string                `json:"codeLanguage"`
+	CodeSnippet        *string                `json:"codeSnippet"`
+	Tags               []string               `json:"tags"`
+	IsPublished        *bool                  `json:"isPublished"`
+	URL                *string                `json:"url"`
+	Series             *string                `json:"series"`
+	Category           *string                `json:"category"`
+	CodeCategoryID     *string                `json:"codeCategoryId"`
+	Difficulty         *Difficulty            `json:"difficulty"`
+	Images             []*MonologueImageInput `json:"images"`
+	RelatedBlogPostIDs []string               `json:"relatedBlogPostIds"`
 }
 
 type UpdateMonologueInput struct {
-	Content        *string      `json:"content"`
-	ContentType    *ContentType `json:"contentType"`
-	CodeLanguage   *string      `json:"codeLanguage"`
-	CodeSnippet    *string      `json:"codeSnippet"`
-	Tags           []string     `json:"tags"`
-	IsPublished    *bool        `json:"isPublished"`
-	URL            *string      `json:"url"`
-	Series         *string      `json:"series"`
-	Category       *string      `json:"category"`
+	Content            *string                `json:"content"`
+	ContentType        *ContentType           `json:"contentType"`
+	CodeLanguage       *string                `json:"codeLanguage"`
+	CodeSnippet        *string                `json:"codeSnippet"`
+	Tags               []string               `json:"tags"`
+	IsPublished        *bool                  `json:"isPublished"`
+	URL                *string                `json:"url"`
+	Series             *string                `json:"series"`
+	Category           *string                `json:"category"`
+	CodeCategoryID     *string                `json:"codeCategoryId"`
+	Difficulty         *Difficulty            `json:"difficulty"`
+	ClearDifficulty    *bool                  `json:"clearDifficulty"`
+	Images             []*MonologueImageInput `json:"images"`
+	RelatedBlogPostIDs []string               `json:"relatedBlogPostIds"`
+}
+
+// MonologueImageInput refers to an uploaded media item by MediaID or to an external image
+// by URL. Dimensions and placeholders are taken from the media item when one is given.
+type MonologueImageInput struct {
+	MediaID *string `json:"mediaId"`
+	URL     *string `json:"url"`
+	Alt     *string `json:"alt"`
+	Caption *string `json:"caption"`
+	Width   *int    `json:"width"`
+	Height  *int    `json:"height"`
+}
+
+type CreateSeriesInput struct {
+	Title       string  `json:"title"`
+	Slug        *string `json:"slug"`
+	Description *string `json:"description"`
+}
+
+type UpdateSeriesInput struct {
+	Title       *string `json:"title"`
+	Slug        *string `json:"slug"`
+	Description *string `json:"description"`
+}
+
+type CreateCodeCategoryInput struct {
+	Name        string  `json:"name"`
+	Slug        *string `json:"slug"`
+	Description *string `json:"description"`
+	ParentID    *string `json:"parentId"`
+	Color       *string `json:"color"`
+	Icon        *string `json:"icon"`
+}
+
+type UpdateCodeCategoryInput struct {
+	Name        *string `json:"name"`
+	Slug        *string `json:"slug"`
+	Description *string `json:"description"`
+	ParentID    *string `json:"parentId"`
+	Color       *string `json:"color"`
+	Icon        *string `json:"icon"`
+}
+
+type CreateProfileInput struct {
+	Name      string  `json:"name"`
+	Title     *string `json:"title"`
+	Bio       *string `json:"bio"`
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+type UpdateProfileInput struct {
+	Name      *string `json:"name"`
+	Title     *string `json:"title"`
+	Bio       *string `json:"bio"`
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+type CreateSocialLinkInput struct {
+	Platform string  `json:"platform"`
+	URL      string  `json:"url"`
+	Icon     *string `json:"icon"`
+}
+
+type UpdateSocialLinkInput struct {
+	Platform *string `json:"platform"`
+	URL      *string `json:"url"`
+	Icon     *string `json:"icon"`
+}
+
+type CreateSkillInput struct {
+	Name         string  `json:"name"`
+	Category     string  `json:"category"`
+	Level        int     `json:"level"`
+	IconURL      *string `json:"iconUrl"`
+	DisplayOrder *int    `json:"displayOrder"`
+}
+
+type UpdateSkillInput struct {
+	Name         *string `json:"name"`
+	Category     *string `json:"category"`
+	Level        *int    `json:"level"`
+	IconURL      *string `json:"iconUrl"`
+	DisplayOrder *int    `json:"displayOrder"`
 }
 
+type CreateExperienceInput struct {
+	Company      string   `json:"company"`
+	Position     string   `json:"position"`
+	Description  *string  `json:"description"`
+	StartDate    string   `json:"startDate"`
+	EndDate      *string  `json:"endDate"`
+	IsCurrent    *bool    `json:"isCurrent"`
+	Technologies []string `json:"technologies"`
+}
+
+type UpdateExperienceInput struct {
+	Company      *string  `json:"company"`
+	Position     *string  `json:"position"`
+	Description  *string  `json:"description"`
+	StartDate    *string  `json:"startDate"`
+	EndDate      *string  `json:"endDate"`
+	IsCurrent    *bool    `json:"isCurrent"`
+	Technologies []string `json:"technologies"`
+}
+
+// Node interface implementations
+
+func (Profile) IsNode()         {}
+func (p Profile) GetID() string { return p.ID }
+
+func (Skill) IsNode()         {}
+func (s Skill) GetID() string { return s.ID }
+
+func (Experience) IsNode()         {}
+func (e Experience) GetID() string { return e.ID }
+
+func (Monologue) IsNode()         {}
+func (m Monologue) GetID() string { return m.ID }
+
+func (BlogPost) IsNode()         {}
+func (b BlogPost) GetID() string { return b.ID }
+
 // GraphQL Marshaler methods for enums
 
 func (c ContentType) MarshalGQL(w io.Writer) {
@@ -204,9 +581,19 @@ func (c *ContentType) UnmarshalGQL(v interface{}) error {
 		return fmt.Errorf("ContentType must be a string")
 	}
 	*c = ContentType(s)
+	if !c.IsValid() {
+		return fmt.Errorf("%s is not a valid ContentType", s)
+	}
 	return nil
 }
 
+func (c ContentType) IsValid() bool {
+	switch c {
+	case ContentTypePost, ContentTypeCode, ContentTypeImage, ContentTypeURLPreview, ContentTypeBlog:
+		return true
+	}
+	return false
+}
 
 func (b BlogStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(string(b)))
@@ -218,5 +605,40 @@ func (b *BlogStatus) UnmarshalGQL(v interface{}) error {
 		return fmt.Errorf("BlogStatus must be a string")
 	}
 	*b = BlogStatus(s)
+	if !b.IsValid() {
+		return fmt.Errorf("%s is not a valid BlogStatus", s)
+	}
+	return nil
+}
+
+func (b BlogStatus) IsValid() bool {
+	switch b {
+	case BlogStatusDraft, BlogStatusPublished, BlogStatusArchived:
+		return true
+	}
+	return false
+}
+
+func (d Difficulty) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(d)))
+}
+
+func (d *Difficulty) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Difficulty must be a string")
+	}
+	*d = Difficulty(s)
+	if !d.IsValid() {
+		return fmt.Errorf("%s is not a valid Difficulty", s)
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (d Difficulty) IsValid() bool {
+	switch d {
+	case DifficultyBeginner, DifficultyIntermediate, DifficultyAdvanced:
+		return true
+	}
+	return false
+}
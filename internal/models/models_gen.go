@@ -2,8 +2,16 @@
 
 package models
 
+type Node interface {
+	IsNode()
+	GetID() string
+}
+
 type Mutation struct {
 }
 
 type Query struct {
 }
+
+type Subscription struct {
+}
@@ -0,0 +1,211 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"time"
+)
+
+// Defaults for NewClient
+const (
+	DefaultTimeout      = 10 * time.Second
+	DefaultMaxRedirects = 5
+)
+
+// ErrBlocked is wrapped by every error returned for a request the client refuses to make
+var ErrBlocked = errors.New("outbound request blocked")
+
+// BlockedError explains why a request was refused
+type BlockedError struct {
+	URL    string
+	Reason string
+}
+
+func (e *BlockedError) Error() string {
+	if e.URL == "" {
+		return fmt.Sprintf("%s: %s", ErrBlocked, e.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", ErrBlocked, e.URL, e.Reason)
+}
+
+func (e *BlockedError) Unwrap() error {
+	return ErrBlocked
+}
+
+type Options struct {
+	Timeout      time.Duration
+	MaxRedirects int
+}
+
+// NewClient returns an HTTP client for fetching user-supplied URLs. It only speaks
+// http and https, resolves host names itself and connects to the vetted addresses
+// directly so DNS cannot be re-bound between the check and the connection, refuses
+// private, loopback, link-local and other non-public addresses, and applies the same
+// checks to every redirect hop. Proxy settings from the environment are ignored, since a
+// proxy would connect on the client's behalf without these checks.
+func NewClient(opts Options) *http.Client {
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.MaxRedirects == 0 {
+		opts.MaxRedirects = DefaultMaxRedirects
+	}
+
+	d := &dialer{
+		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{Timeout: opts.Timeout, KeepAlive: 30 * time.Second},
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           d.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   opts.Timeout,
+		ResponseHeaderTimeout: opts.Timeout,
+	}
+
+	return &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: schemeCheck{next: transport},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > opts.MaxRedirects {
+				return &BlockedError{URL: req.URL.String(), Reason: fmt.Sprintf("more than %d redirects", opts.MaxRedirects)}
+			}
+			return checkScheme(req)
+		},
+	}
+}
+
+// schemeCheck refuses non-http(s) URLs before they reach the transport
+type schemeCheck struct {
+	next http.RoundTripper
+}
+
+func (s schemeCheck) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := checkScheme(req); err != nil {
+		return nil, err
+	}
+	return s.next.RoundTrip(req)
+}
+
+func checkScheme(req *http.Request) error {
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return &BlockedError{URL: req.URL.String(), Reason: fmt.Sprintf("scheme %q is not allowed", req.URL.Scheme)}
+	}
+	return nil
+}
+
+type dialer struct {
+	resolver *net.Resolver
+	dialer   *net.Dialer
+}
+
+// DialContext resolves the host, refuses it if any of its addresses is not public and
+// then connects to the vetted addresses only
+func (d *dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := d.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if reason := BlockedReason(addr); reason != "" {
+			return nil, &BlockedError{Reason: fmt.Sprintf("%s resolves to %s address %s", host, reason, addr.Unmap())}
+		}
+	}
+
+	var lastErr error
+	for _, addr := range addrs {
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(addr.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no addresses for %s", host)
+	}
+	return nil, lastErr
+}
+
+// blockedPrefixes are special-purpose ranges not covered by the netip predicates
+var blockedPrefixes = []struct {
+	prefix netip.Prefix
+	reason string
+}{
+	{netip.MustParsePrefix("0.0.0.0/8"), "unspecified"},
+	{netip.MustParsePrefix("100.64.0.0/10"), "shared address space"},
+	{netip.MustParsePrefix("192.0.0.0/24"), "IETF protocol assignment"},
+	{netip.MustParsePrefix("192.0.2.0/24"), "documentation"},
+	{netip.MustParsePrefix("198.18.0.0/15"), "benchmarking"},
+	{netip.MustParsePrefix("198.51.100.0/24"), "documentation"},
+	{netip.MustParsePrefix("203.0.113.0/24"), "documentation"},
+	{netip.MustParsePrefix("240.0.0.0/4"), "reserved"},
+	{netip.MustParsePrefix("100::/64"), "discard-only"},
+	{netip.MustParsePrefix("2001:db8::/32"), "documentation"},
+	{netip.MustParsePrefix("fec0::/10"), "site-local"},
+}
+
+// BlockedReason returns why addr must not be contacted, or "" for public addresses.
+// IPv6 addresses that embed an IPv4 address (IPv4-mapped, NAT64 and 6to4) are judged by
+// the embedded address.
+func BlockedReason(addr netip.Addr) string {
+	addr = addr.Unmap()
+	if embedded, ok := embeddedIPv4(addr); ok {
+		return BlockedReason(embedded)
+	}
+
+	switch {
+	case !addr.IsValid():
+		return "invalid"
+	case addr.IsUnspecified():
+		return "unspecified"
+	case addr.IsLoopback():
+		return "loopback"
+	case addr.IsPrivate():
+		return "private"
+	case addr.IsLinkLocalUnicast():
+		// includes 169.254.169.254, the cloud metadata endpoint
+		return "link-local"
+	case addr.IsMulticast(), addr.IsLinkLocalMulticast(), addr.IsInterfaceLocalMulticast():
+		return "multicast"
+	case addr == netip.AddrFrom4([4]byte{255, 255, 255, 255}):
+		return "broadcast"
+	}
+
+	for _, blocked := range blockedPrefixes {
+		if blocked.prefix.Contains(addr) {
+			return blocked.reason
+		}
+	}
+	return ""
+}
+
+var (
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFour   = netip.MustParsePrefix("2002::/16")
+)
+
+func embeddedIPv4(addr netip.Addr) (netip.Addr, bool) {
+	if !addr.Is6() {
+		return netip.Addr{}, false
+	}
+	b := addr.As16()
+	switch {
+	case nat64Prefix.Contains(addr):
+		return netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]}), true
+	case sixToFour.Contains(addr):
+		return netip.AddrFrom4([4]byte{b[2], b[3], b[4], b[5]}), true
+	}
+	return netip.Addr{}, false
+}
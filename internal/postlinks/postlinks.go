@@ -0,0 +1,74 @@
+package postlinks
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// linkPattern matches absolute URLs and root-relative paths that start a word, a Markdown
+// link target or an HTML attribute value
+var linkPattern = regexp.MustCompile(`(?:^|[\s(\[<"'=])((?:https?://[^/\s)\]>"'<]+)?/[^\s)\]>"'<]*)`)
+
+// Detector finds links to blog posts in text. Posts live at <blog URL>/<slug>.
+type Detector struct {
+	host string
+	path string
+}
+
+// NewDetector returns a Detector for posts under blogURL, e.g. "https://example.com/blog".
+// A relative blogURL such as "/blog" only detects root-relative links.
+func NewDetector(blogURL string) *Detector {
+	u, err := url.Parse(strings.TrimSpace(blogURL))
+	if err != nil {
+		return &Detector{path: "/blog"}
+	}
+	return &Detector{
+		host: canonicalHost(u.Hostname()),
+		path: "/" + strings.Trim(u.Path, "/"),
+	}
+}
+
+// Slugs returns the slugs of the posts text links to, in order of first appearance
+func (d *Detector) Slugs(text string) []string {
+	if d == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var slugs []string
+	for _, match := range linkPattern.FindAllStringSubmatch(text, -1) {
+		postSlug, ok := d.slug(match[1])
+		if ok && !seen[postSlug] {
+			seen[postSlug] = true
+			slugs = append(slugs, postSlug)
+		}
+	}
+	return slugs
+}
+
+// slug returns the post slug of link, if link points to a post
+func (d *Detector) slug(link string) (string, bool) {
+	u, err := url.Parse(strings.TrimRight(link, ".,;:!?"))
+	if err != nil {
+		return "", false
+	}
+	if u.Host != "" && (d.host == "" || canonicalHost(u.Hostname()) != d.host) {
+		return "", false
+	}
+
+	prefix := strings.TrimRight(d.path, "/") + "/"
+	if !strings.HasPrefix(u.Path, prefix) {
+		return "", false
+	}
+	postSlug := strings.TrimSuffix(strings.TrimPrefix(u.Path, prefix), "/")
+	if postSlug == "" || strings.Contains(postSlug, "/") {
+		return "", false
+	}
+	return postSlug, true
+}
+
+// canonicalHost treats example.com and www.example.com as the same site
+func canonicalHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
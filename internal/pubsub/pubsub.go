@@ -0,0 +1,103 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// Topic names used by the GraphQL subscriptions
+const (
+	TopicMonologuePublished = "monologue.published"
+	TopicBlogPostPublished  = "blogpost.published"
+)
+
+// LikeCountTopic returns the topic that carries like count updates for a single item
+func LikeCountTopic(id string) string {
+	return "likes." + id
+}
+
+// subscriberBuffer is how many undelivered events a slow subscriber may hold before
+// further events for it are dropped
+const subscriberBuffer = 16
+
+// PubSub is an in-process publish/subscribe broker. Events are only delivered to
+// subscribers of the same process.
+type PubSub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan any]struct{}
+}
+
+func New() *PubSub {
+	return &PubSub{
+		subscribers: make(map[string]map[chan any]struct{}),
+	}
+}
+
+// Publish delivers msg to every current subscriber of topic without blocking.
+// Subscribers whose buffer is full miss the event.
+func (ps *PubSub) Publish(topic string, msg any) {
+	if ps == nil {
+		return
+	}
+
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	for ch := range ps.subscribers[topic] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a subscriber for topic. The returned channel is closed and the
+// subscription removed once ctx is done.
+func (ps *PubSub) Subscribe(ctx context.Context, topic string) <-chan any {
+	ch := make(chan any, subscriberBuffer)
+
+	ps.mu.Lock()
+	if ps.subscribers[topic] == nil {
+		ps.subscribers[topic] = make(map[chan any]struct{})
+	}
+	ps.subscribers[topic][ch] = struct{}{}
+	ps.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		ps.mu.Lock()
+		delete(ps.subscribers[topic], ch)
+		if len(ps.subscribers[topic]) == 0 {
+			delete(ps.subscribers, topic)
+		}
+		ps.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Subscribe is a typed wrapper around PubSub.Subscribe that drops events of any other type
+func Subscribe[T any](ctx context.Context, ps *PubSub, topic string) <-chan T {
+	events := ps.Subscribe(ctx, topic)
+	out := make(chan T, 1)
+
+	go func() {
+		defer close(out)
+		for event := range events {
+			msg, ok := event.(T)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,78 @@
+package relay
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Type is the GraphQL type name encoded into a global ID
+type Type string
+
+const (
+	TypeBlogPost     Type = "BlogPost"
+	TypeMonologue    Type = "Monologue"
+	TypeSkill        Type = "Skill"
+	TypeExperience   Type = "Experience"
+	TypeProfile      Type = "Profile"
+	TypeMedia        Type = "Media"
+	TypeSeries       Type = "Series"
+	TypeCodeCategory Type = "CodeCategory"
+)
+
+var knownTypes = map[Type]bool{
+	TypeBlogPost:     true,
+	TypeMonologue:    true,
+	TypeSkill:        true,
+	TypeExperience:   true,
+	TypeProfile:      true,
+	TypeMedia:        true,
+	TypeSeries:       true,
+	TypeCodeCategory: true,
+}
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ToGlobalID builds the opaque ID exposed to clients from a type and a database ID
+func ToGlobalID(t Type, id string) string {
+	return base64.StdEncoding.EncodeToString([]byte(string(t) + ":" + id))
+}
+
+// FromGlobalID splits a global ID into its type and database ID
+func FromGlobalID(globalID string) (Type, string, error) {
+	raw, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid global ID %q", globalID)
+	}
+
+	typeName, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" || !knownTypes[Type(typeName)] {
+		return "", "", fmt.Errorf("invalid global ID %q", globalID)
+	}
+
+	return Type(typeName), id, nil
+}
+
+// IsUUID reports whether id is a bare database ID rather than a global ID
+func IsUUID(id string) bool {
+	return uuidRegex.MatchString(id)
+}
+
+// LocalID resolves a client-supplied ID to the database ID of the expected type.
+// Bare UUIDs are still accepted for clients that predate global IDs.
+func LocalID(id string, expected Type) (string, error) {
+	if IsUUID(id) {
+		return id, nil
+	}
+
+	t, localID, err := FromGlobalID(id)
+	if err != nil {
+		return "", err
+	}
+	if t != expected {
+		return "", fmt.Errorf("ID %q refers to a %s, expected a %s", id, t, expected)
+	}
+
+	return localID, nil
+}
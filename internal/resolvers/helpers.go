@@ -1,9 +1,31 @@
 package resolvers
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/auth"
+	"github.com/naoya0117/portfolio-v2025-api/internal/database"
+	"github.com/naoya0117/portfolio-v2025-api/internal/locale"
+	"github.com/naoya0117/portfolio-v2025-api/internal/markdown"
+	"github.com/naoya0117/portfolio-v2025-api/internal/media"
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/outbound"
+	"github.com/naoya0117/portfolio-v2025-api/internal/pubsub"
+	"github.com/naoya0117/portfolio-v2025-api/internal/relay"
+	"github.com/naoya0117/portfolio-v2025-api/internal/slug"
+	"github.com/naoya0117/portfolio-v2025-api/internal/textutil"
+	"github.com/naoya0117/portfolio-v2025-api/internal/validation"
 )
 
 // Helper functions
@@ -25,3 +47,854 @@ func min(a, b int) int {
 	return b
 }
 
+// Lengths, in characters, of the text generated for fields an author left empty
+const (
+	excerptLength        = 160
+	seoTitleLength       = 60
+	seoDescriptionLength = 120
+	monologueTitleLength = 50
+)
+
+func blogPostExcerpt(post *models.BlogPost) string {
+	if post.Excerpt != nil && *post.Excerpt != "" {
+		return *post.Excerpt
+	}
+	return textutil.Summarize(post.Content, excerptLength)
+}
+
+func blogPostSeoDescription(post *models.BlogPost) string {
+	if post.SeoDescription != nil && *post.SeoDescription != "" {
+		return *post.SeoDescription
+	}
+	if post.Excerpt != nil && *post.Excerpt != "" {
+		return textutil.Summarize(*post.Excerpt, seoDescriptionLength)
+	}
+	return textutil.Summarize(post.Content, seoDescriptionLength)
+}
+
+// monologueTitle derives a title from the content, falling back to the code snippet or
+// link for monologues whose content has no text of its own
+func monologueTitle(m *models.Monologue) string {
+	if title := textutil.Title(m.Content, monologueTitleLength); title != "" {
+		return title
+	}
+	if m.CodeSnippet != nil {
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(*m.CodeSnippet), "\n")
+		if title := textutil.Truncate(firstLine, monologueTitleLength); title != "" {
+			return title
+		}
+	}
+	if m.URLPreview != nil && m.URLPreview.Title != "" {
+		return textutil.Truncate(m.URLPreview.Title, monologueTitleLength)
+	}
+	if m.URL != nil {
+		return textutil.Truncate(*m.URL, monologueTitleLength)
+	}
+	return ""
+}
+
+// loadNode fetches the object behind a global ID. Unknown objects resolve to nil, and so
+// do drafts and unpublished monologues unless the caller is an admin.
+func (r *Resolver) loadNode(ctx context.Context, id string) (models.Node, error) {
+	t, localID, err := relay.FromGlobalID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case relay.TypeBlogPost:
+		post, err := r.DB.GetBlogPostByID(localID)
+		if err != nil || post == nil || (post.Status == models.BlogStatusDraft && !auth.IsAdmin(ctx)) {
+			return nil, err
+		}
+		return post, nil
+	case relay.TypeMonologue:
+		mono, err := r.DB.GetMonologueByID(localID)
+		if err != nil || mono == nil || (!mono.IsPublished && !auth.IsAdmin(ctx)) {
+			return nil, err
+		}
+		return mono, nil
+	case relay.TypeSkill:
+		skill, err := r.DB.GetSkillByID(localID)
+		if err != nil || skill == nil {
+			return nil, err
+		}
+		return skill, nil
+	case relay.TypeExperience:
+		exp, err := r.DB.GetExperienceByID(localID)
+		if err != nil || exp == nil {
+			return nil, err
+		}
+		return exp, nil
+	case relay.TypeProfile:
+		profile, err := r.DB.GetProfile(localID)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return profile, nil
+	case relay.TypeMedia:
+		m, err := r.DB.GetMediaByID(localID)
+		if err != nil || m == nil {
+			return nil, err
+		}
+		return m, nil
+	case relay.TypeCodeCategory:
+		category, err := r.DB.GetCodeCategoryByID(localID)
+		if err != nil || category == nil {
+			return nil, err
+		}
+		return category, nil
+	case relay.TypeSeries:
+		series, err := r.DB.GetSeriesByID(localID)
+		if err != nil || series == nil {
+			return nil, err
+		}
+		return series, nil
+	}
+
+	return nil, nil
+}
+
+// contentItems resolves global IDs and bare UUIDs of blog posts and monologues. Bare UUIDs
+// that are not a blog post are taken to be monologues. Each ID gets either an item or an
+// error at its index.
+func (r *Resolver) contentItems(ids []string) ([]database.BulkItem, []error, error) {
+	var uuids []string
+	for _, id := range ids {
+		if relay.IsUUID(id) {
+			uuids = append(uuids, id)
+		}
+	}
+	blogPostIDs, err := r.DB.BlogPostIDs(uuids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]database.BulkItem, len(ids))
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		t := relay.TypeMonologue
+		if blogPostIDs[id] {
+			t = relay.TypeBlogPost
+		} else if !relay.IsUUID(id) {
+			t, _, errs[i] = relay.FromGlobalID(id)
+			if errs[i] == nil && t != relay.TypeBlogPost && t != relay.TypeMonologue {
+				errs[i] = fmt.Errorf("ID refers to a %s, expected a BlogPost or Monologue", t)
+			}
+			if errs[i] != nil {
+				continue
+			}
+		}
+
+		localID, err := relay.LocalID(id, t)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		items[i] = database.BulkItem{BlogPost: t == relay.TypeBlogPost, ID: localID}
+	}
+	return items, errs, nil
+}
+
+// bulkOperation decodes the IDs, runs op over the ones that refer to a blog post
+// or monologue and merges everything into per-ID results in input order. onChanged, if
+// set, is called for every item that op actually changed.
+func (r *Resolver) bulkOperation(ids []string, op func([]database.BulkItem) ([]database.BulkResult, error), onChanged func(database.BulkItem)) (*models.BulkOperationResult, error) {
+	decoded, decodeErrs, err := r.contentItems(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.BulkItemResult, len(ids))
+	items := make([]database.BulkItem, 0, len(ids))
+	positions := make([]int, 0, len(ids))
+
+	for i, id := range ids {
+		results[i] = &models.BulkItemResult{ID: id}
+		if decodeErrs[i] != nil {
+			results[i].Error = stringPtr(decodeErrs[i].Error())
+			continue
+		}
+
+		items = append(items, decoded[i])
+		positions = append(positions, i)
+	}
+
+	if len(items) > 0 {
+		outcomes, err := op(items)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, outcome := range outcomes {
+			result := results[positions[j]]
+			if outcome.Err != nil {
+				result.Error = stringPtr(outcome.Err.Error())
+				continue
+			}
+			result.Success = true
+			result.Changed = outcome.Changed
+			if outcome.Changed && onChanged != nil {
+				onChanged(items[j])
+			}
+		}
+	}
+
+	response := &models.BulkOperationResult{Results: results}
+	for _, result := range results {
+		if result.Success {
+			response.SuccessCount++
+		} else {
+			response.FailureCount++
+		}
+	}
+
+	return response, nil
+}
+
+// warmURLPreview makes sure the preview for a monologue's URL is stored, since reads only
+// return stored previews. A page that cannot be fetched only leaves the monologue without
+// a preview.
+func (r *Resolver) warmURLPreview(ctx context.Context, mono *models.Monologue) {
+	if mono == nil || mono.URL == nil || *mono.URL == "" {
+		return
+	}
+	preview, err := r.URLPreviews.Get(ctx, *mono.URL)
+	if err != nil {
+		fmt.Printf("[PREVIEW] Failed to fetch preview for %s: %v\n", *mono.URL, err)
+		return
+	}
+	mono.URLPreview = preview
+}
+
+// addItemError reports err for the item at index of the list field being resolved, so
+// the item can be null while the rest of the list is still returned
+func addItemError(ctx context.Context, index int, err error) {
+	graphql.AddError(ctx, &gqlerror.Error{
+		Err:     err,
+		Message: err.Error(),
+		Path:    append(graphql.GetPath(ctx), ast.PathIndex(index)),
+	})
+}
+
+// previewError gives URLs the outbound client refused a stable error code clients can
+// match on, instead of a generic fetch failure
+func previewError(err error) error {
+	if !errors.Is(err, outbound.ErrBlocked) {
+		return err
+	}
+	return &gqlerror.Error{
+		Err:     err,
+		Message: err.Error(),
+		Extensions: map[string]interface{}{
+			"code": "URL_NOT_ALLOWED",
+		},
+	}
+}
+
+// uploadError turns files the media processor rejected into validation errors on the file
+// argument. Other errors, such as storage failures, are returned as is.
+func uploadError(err error) error {
+	if errors.Is(err, media.ErrTooLarge) || errors.Is(err, media.ErrUnsupportedType) || errors.Is(err, media.ErrTooManyPixels) {
+		return validation.MediaFile(err)
+	}
+	return err
+}
+
+// mediaURLs lists the public URLs of an upload and its variants
+func (r *Resolver) mediaURLs(m *models.Media) []string {
+	urls := []string{r.Uploads.URL(m.StorageKey)}
+	for _, variant := range m.Variants {
+		urls = append(urls, r.Uploads.URL(variant.StorageKey))
+	}
+	return urls
+}
+
+// resolveMonologueImages turns image inputs into images, filling in the URL, dimensions,
+// placeholders and default alt text of uploaded media. Linked images are downloaded for
+// their placeholders and missing dimensions. nil stays nil so updates without images keep
+// the current ones.
+func (r *Resolver) resolveMonologueImages(ctx context.Context, inputs []*models.MonologueImageInput) ([]*models.MonologueImage, error) {
+	if inputs == nil {
+		return nil, nil
+	}
+
+	images := make([]*models.MonologueImage, 0, len(inputs))
+	var errs validation.Errors
+	for i, input := range inputs {
+		image := &models.MonologueImage{
+			Alt:     input.Alt,
+			Caption: input.Caption,
+			Width:   input.Width,
+			Height:  input.Height,
+		}
+		if input.URL != nil {
+			image.URL = *input.URL
+		}
+
+		if input.MediaID != nil {
+			mediaID, err := relay.LocalID(*input.MediaID, relay.TypeMedia)
+			if err != nil {
+				return nil, err
+			}
+			m, err := r.DB.GetMediaByID(mediaID)
+			if err != nil {
+				return nil, err
+			}
+			if m == nil {
+				errs.Add(fmt.Sprintf("input.images[%d].mediaId", i), "media not found")
+				continue
+			}
+
+			image.MediaID = &m.ID
+			image.URL = r.Uploads.URL(m.StorageKey)
+			image.Width = &m.Width
+			image.Height = &m.Height
+			image.Blurhash = m.Blurhash
+			image.DominantColor = m.DominantColor
+			if image.Alt == nil || *image.Alt == "" {
+				image.Alt = m.Alt
+			}
+		} else if image.URL != "" {
+			r.inspectLinkedImage(ctx, image)
+		}
+
+		images = append(images, image)
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// inspectLinkedImage fills in the placeholders of an image linked by URL, and its
+// dimensions unless the caller gave them. An image that cannot be downloaded is kept
+// without them.
+func (r *Resolver) inspectLinkedImage(ctx context.Context, image *models.MonologueImage) {
+	remote, err := r.Uploads.Inspect(ctx, image.URL)
+	if err != nil {
+		fmt.Printf("[MEDIA] Failed to inspect linked image %s: %v\n", image.URL, err)
+		return
+	}
+
+	image.Blurhash = remote.Blurhash
+	image.DominantColor = remote.DominantColor
+	if image.Width == nil || image.Height == nil {
+		image.Width = &remote.Width
+		image.Height = &remote.Height
+	}
+}
+
+// publishError reports content that cannot be published, scheduled or archived as
+// validation errors
+func publishError(err error) error {
+	if errors.Is(err, database.ErrImagesRequired) || errors.Is(err, database.ErrImageAltRequired) {
+		return validation.PublishMonologue(err)
+	}
+	if errors.Is(err, database.ErrParentNotPublished) {
+		var errs validation.Errors
+		errs.Add("parentId", "%s", err.Error())
+		return errs.Err()
+	}
+	if errors.Is(err, database.ErrNotSchedulable) || errors.Is(err, database.ErrNotArchivable) {
+		var errs validation.Errors
+		errs.Add("id", "%s", err.Error())
+		return errs.Err()
+	}
+	return err
+}
+
+// NotifyPublished tells subscribers about content the scheduler published
+func (r *Resolver) NotifyPublished(blogPostIDs, monologueIDs []string) {
+	for _, id := range blogPostIDs {
+		if post, err := r.DB.GetBlogPostByID(id); err == nil && post != nil {
+			r.PubSub.Publish(pubsub.TopicBlogPostPublished, post)
+		}
+	}
+	for _, id := range monologueIDs {
+		if mono, err := r.DB.GetMonologueByID(id); err == nil && mono != nil {
+			r.PubSub.Publish(pubsub.TopicMonologuePublished, mono)
+		}
+	}
+}
+
+func scheduledBlogPost(post *models.BlogPost) *models.ScheduledContent {
+	item := &models.ScheduledContent{
+		ID:       relay.ToGlobalID(relay.TypeBlogPost, post.ID),
+		Type:     models.ContentTypeBlog,
+		Title:    post.Title,
+		BlogPost: post,
+	}
+	if post.ScheduledAt != nil {
+		item.ScheduledAt = *post.ScheduledAt
+	}
+	return item
+}
+
+func (r *Resolver) scheduledMonologue(mono *models.Monologue) *models.ScheduledContent {
+	item := &models.ScheduledContent{
+		ID:        relay.ToGlobalID(relay.TypeMonologue, mono.ID),
+		Type:      mono.ContentType,
+		Title:     monologueTitle(mono),
+		Monologue: mono,
+	}
+	if mono.ScheduledAt != nil {
+		item.ScheduledAt = *mono.ScheduledAt
+	}
+	return item
+}
+
+// checkPreviewToken verifies that token grants access to the content t/id
+func checkPreviewToken(token string, t relay.Type, id string) error {
+	claims, err := auth.ParsePreviewToken(token)
+	if err != nil {
+		return previewTokenError(err)
+	}
+	if claims.ContentType != string(t) || claims.ContentID != id {
+		return previewTokenError(errPreviewOtherContent)
+	}
+	return nil
+}
+
+var errPreviewOtherContent = fmt.Errorf("%w: the token was issued for other content", auth.ErrInvalidPreviewToken)
+
+// previewTokenError gives rejected preview tokens a stable error code, so the frontend
+// can tell an expired preview link from a missing post
+func previewTokenError(err error) error {
+	return &gqlerror.Error{
+		Err:     err,
+		Message: err.Error(),
+		Extensions: map[string]interface{}{
+			"code": "INVALID_PREVIEW_TOKEN",
+		},
+	}
+}
+
+// blogPostBySlug returns the published or archived post with slug, or the post a preview
+// token was issued for. Previous slugs resolve to the post with RedirectTo set.
+func (r *Resolver) blogPostBySlug(slug string, previewToken *string) (*models.BlogPost, error) {
+	if previewToken == nil {
+		return r.publicBlogPostBySlug(slug)
+	}
+
+	post, err := r.previewBlogPost(slug, *previewToken)
+	if err == nil || !errors.Is(err, auth.ErrInvalidPreviewToken) {
+		return post, err
+	}
+	// Preview links keep working once the post is published, after the token expired
+	public, publicErr := r.publicBlogPostBySlug(slug)
+	if publicErr != nil || public != nil {
+		return public, publicErr
+	}
+	return nil, err
+}
+
+// publicBlogPostBySlug is blogPostBySlug for callers without a preview token
+func (r *Resolver) publicBlogPostBySlug(slug string) (*models.BlogPost, error) {
+	post, err := r.DB.GetBlogPostBySlug(slug)
+	if err != nil || post != nil {
+		return post, err
+	}
+
+	// Previous slugs resolve to the post with a hint to redirect to the current one
+	id, err := r.DB.GetBlogPostIDBySlugHistory(slug)
+	if err != nil || id == "" {
+		return nil, err
+	}
+	post, err = r.DB.GetBlogPostByID(id)
+	if err != nil || post == nil || post.Status == models.BlogStatusDraft {
+		return nil, err
+	}
+	redirectTo := post.Slug
+	post.RedirectTo = &redirectTo
+	return post, nil
+}
+
+// localizeBlogPosts returns posts in their translation for the requested locale, if any.
+// Tags that are not valid leave the posts in their original locale.
+func (r *Resolver) localizeBlogPosts(requested *string, posts ...*models.BlogPost) error {
+	if requested == nil {
+		return nil
+	}
+	return r.DB.LocalizeBlogPosts(posts, *requested)
+}
+
+// normalizeLocale returns the canonical form of a validated language tag
+func normalizeLocale(tag *string) *string {
+	if tag == nil {
+		return nil
+	}
+	normalized, err := locale.Normalize(*tag)
+	if err != nil {
+		return tag
+	}
+	return &normalized
+}
+
+// translationError reports locale conflicts between a post and its translations as
+// validation errors
+func translationError(err error) error {
+	if errors.Is(err, database.ErrTranslationIsOriginal) || errors.Is(err, database.ErrOriginalLocaleTranslated) {
+		var errs validation.Errors
+		errs.Add("input.locale", "%s", err.Error())
+		return errs.Err()
+	}
+	return err
+}
+
+// previewBlogPost returns the post a preview token was issued for, published or not.
+// slug may be one of the post's previous slugs, in which case RedirectTo is set.
+func (r *Resolver) previewBlogPost(slug, token string) (*models.BlogPost, error) {
+	claims, err := auth.ParsePreviewToken(token)
+	if err != nil {
+		return nil, previewTokenError(err)
+	}
+	if claims.ContentType != string(relay.TypeBlogPost) {
+		return nil, previewTokenError(errPreviewOtherContent)
+	}
+
+	post, err := r.DB.GetBlogPostByID(claims.ContentID)
+	if err != nil || post == nil {
+		return nil, err
+	}
+	if post.Slug == slug {
+		return post, nil
+	}
+
+	previousID, err := r.DB.GetBlogPostIDBySlugHistory(slug)
+	if err != nil {
+		return nil, err
+	}
+	if previousID != post.ID {
+		return nil, previewTokenError(errPreviewOtherContent)
+	}
+	redirectTo := post.Slug
+	post.RedirectTo = &redirectTo
+	return post, nil
+}
+
+// archiveYears groups month counts, which are sorted newest first, by year
+func archiveYears(months []*models.ArchiveMonth) []*models.ArchiveYear {
+	years := []*models.ArchiveYear{}
+	for _, month := range months {
+		if len(years) == 0 || years[len(years)-1].Year != month.Year {
+			years = append(years, &models.ArchiveYear{Year: month.Year})
+		}
+		year := years[len(years)-1]
+		year.Count += month.Count
+		year.Months = append(year.Months, month)
+	}
+	return years
+}
+
+func (r *Resolver) archiveItem(entry *database.ContentEntry) *models.ArchiveItem {
+	if post := entry.BlogPost; post != nil {
+		item := &models.ArchiveItem{
+			ID:       relay.ToGlobalID(relay.TypeBlogPost, post.ID),
+			Type:     models.ContentTypeBlog,
+			Title:    post.Title,
+			BlogPost: post,
+		}
+		if post.PublishedAt != nil {
+			item.PublishedAt = *post.PublishedAt
+		}
+		return item
+	}
+
+	mono := entry.Monologue
+	item := &models.ArchiveItem{
+		ID:        relay.ToGlobalID(relay.TypeMonologue, mono.ID),
+		Type:      mono.ContentType,
+		Title:     monologueTitle(mono),
+		Monologue: mono,
+	}
+	if mono.PublishedAt != nil {
+		item.PublishedAt = *mono.PublishedAt
+	}
+	return item
+}
+
+// parentMonologueID resolves the parentId of a new monologue to a database ID
+func (r *Resolver) parentMonologueID(id *string) (*string, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	var errs validation.Errors
+	localID, err := relay.LocalID(*id, relay.TypeMonologue)
+	if err != nil {
+		errs.Add("input.parentId", "%s", err.Error())
+		return nil, errs.Err()
+	}
+	parent, err := r.DB.GetMonologueByID(localID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		errs.Add("input.parentId", "monologue not found")
+		return nil, errs.Err()
+	}
+	return &localID, nil
+}
+
+// codeCategoryError reports slugs and parents that cannot be used as validation errors
+func codeCategoryError(err error) error {
+	var errs validation.Errors
+	switch {
+	case errors.Is(err, database.ErrCodeCategorySlugTaken):
+		errs.Add("input.slug", "%s", err.Error())
+	case errors.Is(err, database.ErrCodeCategoryParentNotFound), errors.Is(err, database.ErrCodeCategoryCycle):
+		errs.Add("input.parentId", "%s", err.Error())
+	default:
+		return err
+	}
+	return errs.Err()
+}
+
+// codeCategoryID resolves the code category ID given for field to a database ID. Empty
+// IDs are kept, they clear the category.
+func (r *Resolver) codeCategoryID(field string, id *string) (*string, error) {
+	if id == nil || *id == "" {
+		return id, nil
+	}
+
+	var errs validation.Errors
+	localID, err := relay.LocalID(*id, relay.TypeCodeCategory)
+	if err != nil {
+		errs.Add(field, "%s", err.Error())
+		return nil, errs.Err()
+	}
+	category, err := r.DB.GetCodeCategoryByID(localID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		errs.Add(field, "%s", database.ErrCodeCategoryNotFound.Error())
+		return nil, errs.Err()
+	}
+	return &localID, nil
+}
+
+// relatedBlogPostIDs resolves the relatedBlogPostIds of a monologue input to database IDs
+func (r *Resolver) relatedBlogPostIDs(ids []string) ([]string, error) {
+	if ids == nil {
+		return nil, nil
+	}
+
+	var errs validation.Errors
+	localIDs := make([]string, 0, len(ids))
+	for i, id := range ids {
+		field := fmt.Sprintf("input.relatedBlogPostIds[%d]", i)
+		localID, err := relay.LocalID(id, relay.TypeBlogPost)
+		if err != nil {
+			errs.Add(field, "%s", err.Error())
+			continue
+		}
+		post, err := r.DB.GetBlogPostByID(localID)
+		if err != nil {
+			return nil, err
+		}
+		if post == nil {
+			errs.Add(field, "blog post not found")
+			continue
+		}
+		localIDs = append(localIDs, localID)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return localIDs, nil
+}
+
+// relatedContent describes the blog post or monologue of entry as a recommendation
+func (r *Resolver) relatedContent(entry *database.ContentEntry) *models.RelatedContent {
+	if post := entry.BlogPost; post != nil {
+		item := &models.RelatedContent{
+			ID:       relay.ToGlobalID(relay.TypeBlogPost, post.ID),
+			Title:    post.Title,
+			Type:     models.ContentTypeBlog,
+			Excerpt:  stringPtr(blogPostExcerpt(post)),
+			Tags:     post.Tags,
+			ReadTime: intPtr(post.ReadingTime),
+		}
+		if post.PublishedAt != nil {
+			item.PublishedAt = *post.PublishedAt
+		}
+		return item
+	}
+
+	mono := entry.Monologue
+	item := &models.RelatedContent{
+		ID:       relay.ToGlobalID(relay.TypeMonologue, mono.ID),
+		Title:    monologueTitle(mono),
+		Type:     mono.ContentType,
+		Excerpt:  stringPtr(textutil.Summarize(mono.Content, 100)),
+		Tags:     mono.Tags,
+		ReadTime: intPtr(mono.ReadingTime),
+	}
+	if mono.PublishedAt != nil {
+		item.PublishedAt = *mono.PublishedAt
+	}
+	return item
+}
+
+// seriesItem describes the blog post or monologue of entry at a 1-based position
+func (r *Resolver) seriesItem(entry *database.ContentEntry, position int) *models.SeriesItem {
+	if post := entry.BlogPost; post != nil {
+		return &models.SeriesItem{
+			ID:          relay.ToGlobalID(relay.TypeBlogPost, post.ID),
+			Type:        models.ContentTypeBlog,
+			Title:       post.Title,
+			Position:    position,
+			PublishedAt: post.PublishedAt,
+			BlogPost:    post,
+		}
+	}
+
+	mono := entry.Monologue
+	return &models.SeriesItem{
+		ID:          relay.ToGlobalID(relay.TypeMonologue, mono.ID),
+		Type:        mono.ContentType,
+		Title:       monologueTitle(mono),
+		Position:    position,
+		PublishedAt: mono.PublishedAt,
+		Monologue:   mono,
+	}
+}
+
+// seriesItems lists the items of a series, drafts included when all is set
+func (r *Resolver) seriesItems(seriesID string, all bool) ([]*models.SeriesItem, error) {
+	entries, err := r.DB.GetSeriesItems(seriesID, all)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*models.SeriesItem, len(entries))
+	for i, entry := range entries {
+		items[i] = r.seriesItem(entry, i+1)
+	}
+	return items, nil
+}
+
+func (r *Resolver) seriesNavigation(item database.BulkItem) (*models.SeriesNavigation, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	nav, err := r.DB.GetSeriesNavigation(item)
+	if err != nil || nav == nil {
+		return nil, err
+	}
+
+	result := &models.SeriesNavigation{Series: nav.Series, Position: nav.Position, Total: nav.Total}
+	if nav.Previous != nil {
+		result.Previous = r.seriesItem(nav.Previous, nav.Position-1)
+	}
+	if nav.Next != nil {
+		result.Next = r.seriesItem(nav.Next, nav.Position+1)
+	}
+	return result, nil
+}
+
+// seriesError reports slugs used by another series as validation errors
+func seriesError(err error) error {
+	if errors.Is(err, database.ErrSeriesSlugTaken) {
+		var errs validation.Errors
+		errs.Add("input.slug", "%s", err.Error())
+		return errs.Err()
+	}
+	return err
+}
+
+// tagError reports names that would collide with another tag as validation errors
+func tagError(err error) error {
+	if errors.Is(err, database.ErrTagExists) {
+		var errs validation.Errors
+		errs.Add("name", "%s", err.Error())
+		return errs.Err()
+	}
+	return err
+}
+
+// tagSlugs normalizes tag slugs given by clients, so tags can also be addressed by name
+func tagSlugs(values ...string) []string {
+	slugs := make([]string, len(values))
+	for i, value := range values {
+		slugs[i] = slug.Tag(value)
+	}
+	return slugs
+}
+
+// blogPostContentKey identifies the rendered content of a post in its current locale.
+// Translations change without touching the post, so their updated_at is part of the key.
+func blogPostContentKey(post *models.BlogPost) string {
+	key := markdown.CacheKey("BlogPost", post.ID+":"+post.Locale, post.UpdatedAt)
+	if post.TranslationUpdatedAt != nil {
+		key += fmt.Sprintf(":%d", post.TranslationUpdatedAt.UnixNano())
+	}
+	return key
+}
+
+// codeCategoryCache holds the code category tree, loaded once per operation
+type codeCategoryCache struct {
+	once sync.Once
+	byID map[string]*models.CodeCategory
+	err  error
+}
+
+type codeCategoryCacheKey struct{}
+
+// RequestCache is an operation middleware that lets the resolvers of one query share data
+// they would otherwise load per object, like the code category tree. Mutations change that
+// data between their fields and subscriptions run for a long time, so both load it fresh.
+func RequestCache(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if op := graphql.GetOperationContext(ctx).Operation; op == nil || op.Operation != ast.Query {
+		return next(ctx)
+	}
+	return next(context.WithValue(ctx, codeCategoryCacheKey{}, &codeCategoryCache{}))
+}
+
+// codeCategory returns the category with id from the tree of the operation, loading the
+// tree on first use. Outside a query only the category is loaded.
+func (r *Resolver) codeCategory(ctx context.Context, id string) (*models.CodeCategory, error) {
+	cache, ok := ctx.Value(codeCategoryCacheKey{}).(*codeCategoryCache)
+	if !ok {
+		return r.DB.GetCodeCategoryByID(id)
+	}
+	cache.once.Do(func() {
+		cache.byID, cache.err = r.DB.GetCodeCategoryMap()
+	})
+	if cache.err != nil {
+		return nil, cache.err
+	}
+	return cache.byID[id], nil
+}
+
+// AdminDirective implements @admin, running the field only for admins
+func AdminDirective(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+// errAdminRequired is returned to anonymous callers of admin-only fields
+var errAdminRequired = errors.New("admin authentication required")
+
+// requireAdmin fails unless the request came through the admin endpoint with a valid
+// admin token. /query and /admin/query share one schema, so admin-only fields must check,
+// usually by being marked @admin.
+func requireAdmin(ctx context.Context) error {
+	if auth.IsAdmin(ctx) {
+		return nil
+	}
+	return &gqlerror.Error{
+		Err:     errAdminRequired,
+		Message: errAdminRequired.Error(),
+		Extensions: map[string]interface{}{
+			"code": "FORBIDDEN",
+		},
+	}
+}
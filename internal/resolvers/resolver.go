@@ -5,14 +5,63 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/naoya0117/portfolio-v2025-api/internal/auth"
 	"github.com/naoya0117/portfolio-v2025-api/internal/database"
 	"github.com/naoya0117/portfolio-v2025-api/internal/generated"
+	"github.com/naoya0117/portfolio-v2025-api/internal/highlight"
+	"github.com/naoya0117/portfolio-v2025-api/internal/markdown"
+	"github.com/naoya0117/portfolio-v2025-api/internal/media"
 	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/pubsub"
+	"github.com/naoya0117/portfolio-v2025-api/internal/relay"
+	"github.com/naoya0117/portfolio-v2025-api/internal/textutil"
+	"github.com/naoya0117/portfolio-v2025-api/internal/urlpreview"
+	"github.com/naoya0117/portfolio-v2025-api/internal/validation"
 )
 
-type Resolver struct{ DB *database.DB }
+type Resolver struct {
+	DB          *database.DB
+	PubSub      *pubsub.PubSub
+	Markdown    *markdown.Renderer
+	URLPreviews *urlpreview.Service
+	Uploads     *media.Processor
+}
+
+// ID is the resolver for the id field.
+func (r *blogPostResolver) ID(ctx context.Context, obj *models.BlogPost) (string, error) {
+	return relay.ToGlobalID(relay.TypeBlogPost, obj.ID), nil
+}
+
+// DisplayExcerpt is the resolver for the displayExcerpt field.
+func (r *blogPostResolver) DisplayExcerpt(ctx context.Context, obj *models.BlogPost) (*string, error) {
+	excerpt := blogPostExcerpt(obj)
+	if excerpt == "" {
+		return nil, nil
+	}
+	return &excerpt, nil
+}
+
+// DisplaySeoTitle is the resolver for the displaySeoTitle field.
+func (r *blogPostResolver) DisplaySeoTitle(ctx context.Context, obj *models.BlogPost) (string, error) {
+	if obj.SeoTitle != nil && *obj.SeoTitle != "" {
+		return *obj.SeoTitle, nil
+	}
+	return textutil.Truncate(obj.Title, seoTitleLength), nil
+}
+
+// DisplaySeoDescription is the resolver for the displaySeoDescription field.
+func (r *blogPostResolver) DisplaySeoDescription(ctx context.Context, obj *models.BlogPost) (*string, error) {
+	description := blogPostSeoDescription(obj)
+	if description == "" {
+		return nil, nil
+	}
+	return &description, nil
+}
 
 // BlogPost field resolvers
 func (r *blogPostResolver) CreatedAt(ctx context.Context, obj *models.BlogPost) (string, error) {
@@ -24,6 +73,161 @@ func (r *blogPostResolver) UpdatedAt(ctx context.Context, obj *models.BlogPost)
 	return obj.UpdatedAt.Format(time.RFC3339), nil
 }
 
+// ContentHTML is the resolver for the contentHtml field.
+func (r *blogPostResolver) ContentHTML(ctx context.Context, obj *models.BlogPost) (string, error) {
+	rendered, err := r.Markdown.Render(blogPostContentKey(obj), obj.Content)
+	if err != nil {
+		return "", err
+	}
+	return rendered.HTML, nil
+}
+
+// Toc is the resolver for the toc field.
+func (r *blogPostResolver) Toc(ctx context.Context, obj *models.BlogPost) ([]*models.TocEntry, error) {
+	rendered, err := r.Markdown.Render(blogPostContentKey(obj), obj.Content)
+	if err != nil {
+		return nil, err
+	}
+	return rendered.Toc, nil
+}
+
+// CanonicalSlug is the resolver for the canonicalSlug field.
+func (r *blogPostResolver) CanonicalSlug(ctx context.Context, obj *models.BlogPost) (string, error) {
+	return obj.Slug, nil
+}
+
+// SeriesNavigation is the resolver for the seriesNavigation field.
+func (r *blogPostResolver) SeriesNavigation(ctx context.Context, obj *models.BlogPost) (*models.SeriesNavigation, error) {
+	return r.seriesNavigation(database.BulkItem{BlogPost: true, ID: obj.ID})
+}
+
+// MentionedIn is the resolver for the mentionedIn field.
+func (r *blogPostResolver) MentionedIn(ctx context.Context, obj *models.BlogPost) ([]*models.Monologue, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetMentioningMonologues(obj.ID)
+}
+
+// AvailableLocales is the resolver for the availableLocales field.
+func (r *blogPostResolver) AvailableLocales(ctx context.Context, obj *models.BlogPost) ([]string, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	alternates, err := r.DB.GetBlogPostAlternates(obj)
+	if err != nil {
+		return nil, err
+	}
+	locales := make([]string, len(alternates))
+	for i, alternate := range alternates {
+		locales[i] = alternate.Locale
+	}
+	return locales, nil
+}
+
+// Alternates is the resolver for the alternates field.
+func (r *blogPostResolver) Alternates(ctx context.Context, obj *models.BlogPost) ([]*models.BlogPostAlternate, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetBlogPostAlternates(obj)
+}
+
+// Translations is the resolver for the translations field.
+func (r *blogPostResolver) Translations(ctx context.Context, obj *models.BlogPost) ([]*models.BlogPostTranslation, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetBlogPostTranslations(obj.ID)
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *blogPostTranslationResolver) CreatedAt(ctx context.Context, obj *models.BlogPostTranslation) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *blogPostTranslationResolver) UpdatedAt(ctx context.Context, obj *models.BlogPostTranslation) (string, error) {
+	return obj.UpdatedAt.Format(time.RFC3339), nil
+}
+
+// ID is the resolver for the id field.
+func (r *codeCategoryResolver) ID(ctx context.Context, obj *models.CodeCategory) (string, error) {
+	return relay.ToGlobalID(relay.TypeCodeCategory, obj.ID), nil
+}
+
+// Parent is the resolver for the parent field.
+func (r *codeCategoryResolver) Parent(ctx context.Context, obj *models.CodeCategory) (*models.CodeCategory, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.codeCategory(ctx, *obj.ParentID)
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *codeCategoryResolver) CreatedAt(ctx context.Context, obj *models.CodeCategory) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *codeCategoryResolver) UpdatedAt(ctx context.Context, obj *models.CodeCategory) (string, error) {
+	return obj.UpdatedAt.Format(time.RFC3339), nil
+}
+
+// ID is the resolver for the id field.
+func (r *experienceResolver) ID(ctx context.Context, obj *models.Experience) (string, error) {
+	return relay.ToGlobalID(relay.TypeExperience, obj.ID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *mediaResolver) ID(ctx context.Context, obj *models.Media) (string, error) {
+	return relay.ToGlobalID(relay.TypeMedia, obj.ID), nil
+}
+
+// URL is the resolver for the url field.
+func (r *mediaResolver) URL(ctx context.Context, obj *models.Media) (string, error) {
+	return r.Uploads.URL(obj.StorageKey), nil
+}
+
+// Usages is the resolver for the usages field.
+func (r *mediaResolver) Usages(ctx context.Context, obj *models.Media) ([]*models.MediaUsage, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	usages, err := r.DB.GetMediaUsages(r.mediaURLs(obj))
+	if err != nil {
+		return nil, err
+	}
+	for _, usage := range usages {
+		usage.ID = relay.ToGlobalID(relay.Type(usage.Type), usage.ID)
+	}
+	return usages, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *mediaResolver) CreatedAt(ctx context.Context, obj *models.Media) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// URL is the resolver for the url field.
+func (r *mediaVariantResolver) URL(ctx context.Context, obj *models.MediaVariant) (string, error) {
+	return r.Uploads.URL(obj.StorageKey), nil
+}
+
+// ID is the resolver for the id field.
+func (r *monologueResolver) ID(ctx context.Context, obj *models.Monologue) (string, error) {
+	return relay.ToGlobalID(relay.TypeMonologue, obj.ID), nil
+}
+
+// Title is the resolver for the title field.
+func (r *monologueResolver) Title(ctx context.Context, obj *models.Monologue) (string, error) {
+	return monologueTitle(obj), nil
+}
+
 // Monologue field resolvers
 func (r *monologueResolver) CreatedAt(ctx context.Context, obj *models.Monologue) (string, error) {
 	return obj.CreatedAt.Format(time.RFC3339), nil
@@ -34,123 +238,1070 @@ func (r *monologueResolver) UpdatedAt(ctx context.Context, obj *models.Monologue
 	return obj.UpdatedAt.Format(time.RFC3339), nil
 }
 
-// Mutation resolvers
-func (r *mutationResolver) LikeMonologue(ctx context.Context, id string) (*models.LikeResponse, error) {
+// RelatedBlogPosts is the resolver for the relatedBlogPosts field.
+func (r *monologueResolver) RelatedBlogPosts(ctx context.Context, obj *models.Monologue) ([]*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetRelatedBlogPosts(obj.ID)
+}
+
+// SeriesNavigation is the resolver for the seriesNavigation field.
+func (r *monologueResolver) SeriesNavigation(ctx context.Context, obj *models.Monologue) (*models.SeriesNavigation, error) {
+	return r.seriesNavigation(database.BulkItem{ID: obj.ID})
+}
+
+// CodeCategory is the resolver for the codeCategory field.
+func (r *monologueResolver) CodeCategory(ctx context.Context, obj *models.Monologue) (*models.CodeCategory, error) {
+	if obj.CodeCategoryID == nil {
+		return nil, nil
+	}
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.codeCategory(ctx, *obj.CodeCategoryID)
+}
+
+// ParentID is the resolver for the parentId field.
+func (r *monologueResolver) ParentID(ctx context.Context, obj *models.Monologue) (*string, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+	id := relay.ToGlobalID(relay.TypeMonologue, *obj.ParentID)
+	return &id, nil
+}
+
+// Replies is the resolver for the replies field.
+func (r *monologueResolver) Replies(ctx context.Context, obj *models.Monologue) ([]*models.Monologue, error) {
+	if obj.ReplyCount == 0 {
+		return []*models.Monologue{}, nil
+	}
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetReplies(obj.ID)
+}
+
+// ContentHTML is the resolver for the contentHtml field.
+func (r *monologueResolver) ContentHTML(ctx context.Context, obj *models.Monologue) (string, error) {
+	rendered, err := r.Markdown.Render(markdown.CacheKey("Monologue", obj.ID, obj.UpdatedAt), obj.Content)
+	if err != nil {
+		return "", err
+	}
+	return rendered.HTML, nil
+}
+
+// HighlightedHTML is the resolver for the highlightedHtml field.
+func (r *monologueResolver) HighlightedHTML(ctx context.Context, obj *models.Monologue, theme *string, lineNumbers *bool, highlightLines []int) (*string, error) {
+	if obj.CodeSnippet == nil || *obj.CodeSnippet == "" {
+		return nil, nil
+	}
+
+	language := ""
+	if obj.CodeLanguage != nil {
+		language = *obj.CodeLanguage
+	}
+	selectedTheme := highlight.DefaultTheme
+	if theme != nil && *theme != "" {
+		selectedTheme = *theme
+	}
+
+	html, err := highlight.HTML(*obj.CodeSnippet, language, selectedTheme, highlight.Options{
+		LineNumbers:    lineNumbers != nil && *lineNumbers,
+		HighlightLines: highlightLines,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &html, nil
+}
+
+// Media is the resolver for the media field.
+func (r *monologueImageResolver) Media(ctx context.Context, obj *models.MonologueImage) (*models.Media, error) {
+	if obj.MediaID == nil {
+		return nil, nil
+	}
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetMediaByID(*obj.MediaID)
+}
+
+// Mutation resolvers
+func (r *mutationResolver) LikeMonologue(ctx context.Context, id string) (*models.LikeResponse, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeMonologue)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.DB.LikeMonologue(id)
+	if err != nil {
+		return nil, err
+	}
+	result.ID = relay.ToGlobalID(relay.TypeMonologue, id)
+	r.PubSub.Publish(pubsub.LikeCountTopic(id), result)
+	return result, nil
+}
+
+// LikeBlogPost is the resolver for the likeBlogPost field.
+func (r *mutationResolver) LikeBlogPost(ctx context.Context, id string) (*models.LikeResponse, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeBlogPost)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.DB.LikeBlogPost(id)
+	if err != nil {
+		return nil, err
+	}
+	result.ID = relay.ToGlobalID(relay.TypeBlogPost, id)
+	r.PubSub.Publish(pubsub.LikeCountTopic(id), result)
+	return result, nil
+}
+
+// GenerateURLPreview is the resolver for the generateUrlPreview field.
+func (r *mutationResolver) GenerateURLPreview(ctx context.Context, url string) (*models.URLPreview, error) {
+	if err := validation.PreviewURL(url); err != nil {
+		return nil, err
+	}
+	preview, err := r.URLPreviews.Get(ctx, url)
+	if err != nil {
+		return nil, previewError(err)
+	}
+	return preview, nil
+}
+
+// RefreshURLPreview is the resolver for the refreshUrlPreview field.
+func (r *mutationResolver) RefreshURLPreview(ctx context.Context, url string) (*models.URLPreview, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := validation.PreviewURL(url); err != nil {
+		return nil, err
+	}
+	preview, err := r.URLPreviews.Refresh(ctx, url)
+	if err != nil {
+		return nil, previewError(err)
+	}
+	return preview, nil
+}
+
+// RenameTag is the resolver for the renameTag field.
+func (r *mutationResolver) RenameTag(ctx context.Context, slug string, name string) (*models.Tag, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.RenameTag(name); err != nil {
+		return nil, err
+	}
+	tag, err := r.DB.RenameTag(tagSlugs(slug)[0], strings.TrimSpace(name))
+	if err != nil {
+		return nil, tagError(err)
+	}
+	return tag, nil
+}
+
+// MergeTags is the resolver for the mergeTags field.
+func (r *mutationResolver) MergeTags(ctx context.Context, sources []string, target string) (*models.Tag, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.MergeTags(sources, target); err != nil {
+		return nil, err
+	}
+	return r.DB.MergeTags(tagSlugs(sources...), tagSlugs(target)[0])
+}
+
+// DescribeTag is the resolver for the describeTag field.
+func (r *mutationResolver) DescribeTag(ctx context.Context, slug string, description *string) (*models.Tag, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.DescribeTag(description); err != nil {
+		return nil, err
+	}
+	if description != nil && strings.TrimSpace(*description) == "" {
+		description = nil
+	}
+	return r.DB.DescribeTag(tagSlugs(slug)[0], description)
+}
+
+// CreatePreviewToken is the resolver for the createPreviewToken field.
+func (r *mutationResolver) CreatePreviewToken(ctx context.Context, id string, expiresInMinutes *int) (*models.PreviewToken, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	t, localID, err := relay.FromGlobalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.PreviewToken(expiresInMinutes, int(auth.MaxPreviewTTL/time.Minute)); err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case relay.TypeBlogPost:
+		post, err := r.DB.GetBlogPostByID(localID)
+		if err != nil {
+			return nil, err
+		}
+		if post == nil {
+			return nil, fmt.Errorf("blog post not found")
+		}
+	case relay.TypeMonologue:
+		mono, err := r.DB.GetMonologueByID(localID)
+		if err != nil {
+			return nil, err
+		}
+		if mono == nil {
+			return nil, fmt.Errorf("monologue not found")
+		}
+	default:
+		return nil, fmt.Errorf("ID refers to a %s, expected a BlogPost or Monologue", t)
+	}
+
+	ttl := auth.DefaultPreviewTTL
+	if expiresInMinutes != nil {
+		ttl = time.Duration(*expiresInMinutes) * time.Minute
+	}
+	token, expiresAt, err := auth.NewPreviewToken(string(t), localID, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PreviewToken{Token: token, ExpiresAt: expiresAt.Format(time.RFC3339)}, nil
+}
+
+// ScheduleContent is the resolver for the scheduleContent field.
+func (r *mutationResolver) ScheduleContent(ctx context.Context, id string, publishAt string) (*models.ScheduledContent, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	t, localID, err := relay.FromGlobalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ScheduleContent(publishAt); err != nil {
+		return nil, err
+	}
+	at, _ := time.Parse(time.RFC3339, publishAt)
+
+	switch t {
+	case relay.TypeBlogPost:
+		post, err := r.DB.ScheduleBlogPost(localID, &at)
+		if err != nil {
+			return nil, publishError(err)
+		}
+		if post == nil {
+			return nil, fmt.Errorf("blog post not found")
+		}
+		return scheduledBlogPost(post), nil
+	case relay.TypeMonologue:
+		mono, err := r.DB.ScheduleMonologue(localID, &at)
+		if err != nil {
+			return nil, publishError(err)
+		}
+		if mono == nil {
+			return nil, fmt.Errorf("monologue not found")
+		}
+		return r.scheduledMonologue(mono), nil
+	}
+	return nil, fmt.Errorf("ID refers to a %s, expected a BlogPost or Monologue", t)
+}
+
+// CancelScheduledContent is the resolver for the cancelScheduledContent field.
+func (r *mutationResolver) CancelScheduledContent(ctx context.Context, id string) (bool, error) {
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	t, localID, err := relay.FromGlobalID(id)
+	if err != nil {
+		return false, err
+	}
+
+	switch t {
+	case relay.TypeBlogPost:
+		post, err := r.DB.ScheduleBlogPost(localID, nil)
+		return post != nil, err
+	case relay.TypeMonologue:
+		mono, err := r.DB.ScheduleMonologue(localID, nil)
+		return mono != nil, err
+	}
+	return false, fmt.Errorf("ID refers to a %s, expected a BlogPost or Monologue", t)
+}
+
+// UploadMedia is the resolver for the uploadMedia field.
+func (r *mutationResolver) UploadMedia(ctx context.Context, file graphql.Upload, alt *string) (*models.Media, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.UploadMedia(alt); err != nil {
+		return nil, err
+	}
+
+	m, err := r.Uploads.Process(ctx, file.Filename, file.File)
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	if alt != nil && *alt != "" {
+		m.Alt = alt
+	}
+
+	created, err := r.DB.CreateMedia(m)
+	if err != nil {
+		if removeErr := r.Uploads.Remove(ctx, m); removeErr != nil {
+			fmt.Printf("[MEDIA] Failed to remove files of %s: %v\n", m.StorageKey, removeErr)
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// DeleteMedia is the resolver for the deleteMedia field.
+func (r *mutationResolver) DeleteMedia(ctx context.Context, id string) (bool, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return false, err
+	}
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeMedia)
+	if err != nil {
+		return false, err
+	}
+
+	m, err := r.DB.GetMediaByID(id)
+	if err != nil || m == nil {
+		return false, err
+	}
+
+	deleted, err := r.DB.DeleteMedia(id)
+	if err != nil || !deleted {
+		return deleted, err
+	}
+
+	// The record is gone either way, so leftover files are only logged
+	if err := r.Uploads.Remove(ctx, m); err != nil {
+		fmt.Printf("[MEDIA] Failed to remove files of %s: %v\n", m.StorageKey, err)
+	}
+	return true, nil
+}
+
+// CreateBlogPost is the resolver for the createBlogPost field.
+func (r *mutationResolver) CreateBlogPost(ctx context.Context, input models.CreateBlogPostInput) (*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.CreateBlogPost(input, r.DB.BlogPostSlugExists); err != nil {
+		return nil, err
+	}
+	input.Locale = normalizeLocale(input.Locale)
+	post, err := r.DB.CreateBlogPost(input)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status == models.BlogStatusPublished {
+		r.PubSub.Publish(pubsub.TopicBlogPostPublished, post)
+	}
+	return post, nil
+}
+
+// UpdateBlogPost is the resolver for the updateBlogPost field.
+func (r *mutationResolver) UpdateBlogPost(ctx context.Context, id string, input models.UpdateBlogPostInput) (*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeBlogPost)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.UpdateBlogPost(id, input, r.DB.BlogPostSlugExists); err != nil {
+		return nil, err
+	}
+	input.Locale = normalizeLocale(input.Locale)
+	before, err := r.DB.GetBlogPostByID(id)
+	if err != nil {
+		return nil, err
+	}
+	post, err := r.DB.UpdateBlogPost(id, input)
+	if err != nil {
+		return nil, translationError(err)
+	}
+	if before != nil && before.Status != models.BlogStatusPublished && post.Status == models.BlogStatusPublished {
+		r.PubSub.Publish(pubsub.TopicBlogPostPublished, post)
+	}
+	return post, nil
+}
+
+// DeleteBlogPost is the resolver for the deleteBlogPost field.
+func (r *mutationResolver) DeleteBlogPost(ctx context.Context, id string) (bool, error) {
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeBlogPost)
+	if err != nil {
+		return false, err
+	}
+	return r.DB.DeleteBlogPost(id)
+}
+
+// PublishBlogPost is the resolver for the publishBlogPost field.
+func (r *mutationResolver) PublishBlogPost(ctx context.Context, id string) (*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeBlogPost)
+	if err != nil {
+		return nil, err
+	}
+	before, err := r.DB.GetBlogPostByID(id)
+	if err != nil {
+		return nil, err
+	}
+	post, err := r.DB.PublishBlogPost(id)
+	if err != nil {
+		return nil, err
+	}
+	if before != nil && before.Status != models.BlogStatusPublished {
+		r.PubSub.Publish(pubsub.TopicBlogPostPublished, post)
+	}
+	return post, nil
+}
+
+// UnpublishBlogPost is the resolver for the unpublishBlogPost field.
+func (r *mutationResolver) UnpublishBlogPost(ctx context.Context, id string) (*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeBlogPost)
+	if err != nil {
+		return nil, err
+	}
+	return r.DB.UnpublishBlogPost(id)
+}
+
+// ArchiveBlogPost is the resolver for the archiveBlogPost field.
+func (r *mutationResolver) ArchiveBlogPost(ctx context.Context, id string) (*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeBlogPost)
+	if err != nil {
+		return nil, err
+	}
+	post, err := r.DB.ArchiveBlogPost(id)
+	if err != nil {
+		return nil, publishError(err)
+	}
+	if post == nil {
+		return nil, fmt.Errorf("blog post not found")
+	}
+	return post, nil
+}
+
+// UnarchiveBlogPost is the resolver for the unarchiveBlogPost field.
+func (r *mutationResolver) UnarchiveBlogPost(ctx context.Context, id string) (*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeBlogPost)
+	if err != nil {
+		return nil, err
+	}
+	post, err := r.DB.UnarchiveBlogPost(id)
+	if err != nil {
+		return nil, err
+	}
+	if post == nil {
+		return nil, fmt.Errorf("blog post not found")
+	}
+	return post, nil
+}
+
+// SetBlogPostTranslation is the resolver for the setBlogPostTranslation field.
+func (r *mutationResolver) SetBlogPostTranslation(ctx context.Context, blogPostID string, input models.BlogPostTranslationInput) (*models.BlogPostTranslation, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	blogPostID, err := relay.LocalID(blogPostID, relay.TypeBlogPost)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.BlogPostTranslation(input); err != nil {
+		return nil, err
+	}
+	input.Locale = *normalizeLocale(&input.Locale)
+	translation, err := r.DB.SetBlogPostTranslation(blogPostID, input)
+	if err != nil {
+		return nil, translationError(err)
+	}
+	if translation == nil {
+		return nil, fmt.Errorf("blog post not found")
+	}
+	return translation, nil
+}
+
+// DeleteBlogPostTranslation is the resolver for the deleteBlogPostTranslation field.
+func (r *mutationResolver) DeleteBlogPostTranslation(ctx context.Context, blogPostID string, locale string) (bool, error) {
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	blogPostID, err := relay.LocalID(blogPostID, relay.TypeBlogPost)
+	if err != nil {
+		return false, err
+	}
+	return r.DB.DeleteBlogPostTranslation(blogPostID, *normalizeLocale(&locale))
+}
+
+// CreateCodeCategory is the resolver for the createCodeCategory field.
+func (r *mutationResolver) CreateCodeCategory(ctx context.Context, input models.CreateCodeCategoryInput) (*models.CodeCategory, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.CreateCodeCategory(input); err != nil {
+		return nil, err
+	}
+	if input.ParentID != nil {
+		parentID, err := relay.LocalID(*input.ParentID, relay.TypeCodeCategory)
+		if err != nil {
+			return nil, err
+		}
+		input.ParentID = &parentID
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	category, err := r.DB.CreateCodeCategory(input)
+	if err != nil {
+		return nil, codeCategoryError(err)
+	}
+	return category, nil
+}
+
+// UpdateCodeCategory is the resolver for the updateCodeCategory field.
+func (r *mutationResolver) UpdateCodeCategory(ctx context.Context, id string, input models.UpdateCodeCategoryInput) (*models.CodeCategory, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeCodeCategory)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.UpdateCodeCategory(input); err != nil {
+		return nil, err
+	}
+	if input.ParentID != nil && *input.ParentID != "" {
+		parentID, err := relay.LocalID(*input.ParentID, relay.TypeCodeCategory)
+		if err != nil {
+			return nil, err
+		}
+		input.ParentID = &parentID
+	}
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		input.Name = &name
+	}
+	category, err := r.DB.UpdateCodeCategory(id, input)
+	if err != nil {
+		return nil, codeCategoryError(err)
+	}
+	return category, nil
+}
+
+// DeleteCodeCategory is the resolver for the deleteCodeCategory field.
+func (r *mutationResolver) DeleteCodeCategory(ctx context.Context, id string) (bool, error) {
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeCodeCategory)
+	if err != nil {
+		return false, err
+	}
+	return r.DB.DeleteCodeCategory(id)
+}
+
+// CreateSeries is the resolver for the createSeries field.
+func (r *mutationResolver) CreateSeries(ctx context.Context, input models.CreateSeriesInput) (*models.Series, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.CreateSeries(input); err != nil {
+		return nil, err
+	}
+	input.Title = strings.TrimSpace(input.Title)
+	series, err := r.DB.CreateSeries(input)
+	if err != nil {
+		return nil, seriesError(err)
+	}
+	return series, nil
+}
+
+// UpdateSeries is the resolver for the updateSeries field.
+func (r *mutationResolver) UpdateSeries(ctx context.Context, id string, input models.UpdateSeriesInput) (*models.Series, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeSeries)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.UpdateSeries(input); err != nil {
+		return nil, err
+	}
+	if input.Title != nil {
+		title := strings.TrimSpace(*input.Title)
+		input.Title = &title
+	}
+	series, err := r.DB.UpdateSeries(id, input)
+	if err != nil {
+		return nil, seriesError(err)
+	}
+	if series == nil {
+		return nil, database.ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+// DeleteSeries is the resolver for the deleteSeries field.
+func (r *mutationResolver) DeleteSeries(ctx context.Context, id string) (bool, error) {
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeSeries)
+	if err != nil {
+		return false, err
+	}
+	return r.DB.DeleteSeries(id)
+}
+
+// SetSeriesItems is the resolver for the setSeriesItems field.
+func (r *mutationResolver) SetSeriesItems(ctx context.Context, id string, itemIds []string) (*models.Series, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeSeries)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.SetSeriesItems(itemIds); err != nil {
+		return nil, err
+	}
+
+	items, itemErrs, err := r.contentItems(itemIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range itemErrs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.DB.SetSeriesItems(id, items); err != nil {
+		return nil, err
+	}
+	return r.DB.GetSeriesByID(id)
+}
+
+// CreateMonologue is the resolver for the createMonologue field.
+func (r *mutationResolver) CreateMonologue(ctx context.Context, input models.CreateMonologueInput) (*models.Monologue, error) {
+	fmt.Printf("[RESOLVER] CreateMonologue called with input: %+v\n", input)
+	fmt.Printf("[RESOLVER] ContentType: %s\n", input.ContentType)
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.CreateMonologue(input); err != nil {
+		return nil, err
+	}
+	images, err := r.resolveMonologueImages(ctx, input.Images)
+	if err != nil {
+		return nil, err
+	}
+	if input.CodeCategoryID != nil && *input.CodeCategoryID == "" {
+		input.CodeCategoryID = nil
+	}
+	if input.ParentID, err = r.parentMonologueID(input.ParentID); err != nil {
+		return nil, err
+	}
+	if input.CodeCategoryID, err = r.codeCategoryID("input.codeCategoryId", input.CodeCategoryID); err != nil {
+		return nil, err
+	}
+	if input.RelatedBlogPostIDs, err = r.relatedBlogPostIDs(input.RelatedBlogPostIDs); err != nil {
+		return nil, err
+	}
+	result, err := r.DB.CreateMonologue(input, images)
+	if err != nil {
+		fmt.Printf("[RESOLVER] CreateMonologue error: %v\n", err)
+		return nil, publishError(err)
+	}
+	fmt.Printf("[RESOLVER] CreateMonologue success: %+v\n", result)
+	r.warmURLPreview(ctx, result)
+	if result.IsPublished {
+		r.PubSub.Publish(pubsub.TopicMonologuePublished, result)
+	}
+	return result, nil
+}
+
+// UpdateMonologue is the resolver for the updateMonologue field.
+func (r *mutationResolver) UpdateMonologue(ctx context.Context, id string, input models.UpdateMonologueInput) (*models.Monologue, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeMonologue)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.UpdateMonologue(input); err != nil {
+		return nil, err
+	}
+	images, err := r.resolveMonologueImages(ctx, input.Images)
+	if err != nil {
+		return nil, err
+	}
+	if input.CodeCategoryID, err = r.codeCategoryID("input.codeCategoryId", input.CodeCategoryID); err != nil {
+		return nil, err
+	}
+	if input.RelatedBlogPostIDs, err = r.relatedBlogPostIDs(input.RelatedBlogPostIDs); err != nil {
+		return nil, err
+	}
+	before, err := r.DB.GetMonologueByID(id)
+	if err != nil {
+		return nil, err
+	}
+	mono, err := r.DB.UpdateMonologue(id, input, images)
+	if err != nil {
+		return nil, publishError(err)
+	}
+	if input.URL != nil && (before == nil || before.URL == nil || *before.URL != *input.URL) {
+		r.warmURLPreview(ctx, mono)
+	}
+	if before != nil && !before.IsPublished && mono != nil && mono.IsPublished {
+		r.PubSub.Publish(pubsub.TopicMonologuePublished, mono)
+	}
+	return mono, nil
+}
+
+// DeleteMonologue is the resolver for the deleteMonologue field.
+func (r *mutationResolver) DeleteMonologue(ctx context.Context, id string) (bool, error) {
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeMonologue)
+	if err != nil {
+		return false, err
+	}
+	return r.DB.DeleteMonologue(id)
+}
+
+// PublishMonologue is the resolver for the publishMonologue field.
+func (r *mutationResolver) PublishMonologue(ctx context.Context, id string) (*models.Monologue, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeMonologue)
+	if err != nil {
+		return nil, err
+	}
+	before, err := r.DB.GetMonologueByID(id)
+	if err != nil {
+		return nil, err
+	}
+	mono, err := r.DB.PublishMonologue(id)
+	if err != nil {
+		return nil, publishError(err)
+	}
+	if before != nil && !before.IsPublished && mono != nil {
+		r.PubSub.Publish(pubsub.TopicMonologuePublished, mono)
+	}
+	return mono, nil
+}
+
+// UnpublishMonologue is the resolver for the unpublishMonologue field.
+func (r *mutationResolver) UnpublishMonologue(ctx context.Context, id string) (*models.Monologue, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeMonologue)
+	if err != nil {
+		return nil, err
+	}
+	return r.DB.UnpublishMonologue(id)
+}
+
+// BulkPublish is the resolver for the bulkPublish field.
+func (r *mutationResolver) BulkPublish(ctx context.Context, ids []string) (*models.BulkOperationResult, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.BulkIDs(ids); err != nil {
+		return nil, err
+	}
+	return r.bulkOperation(ids, r.DB.BulkPublish, func(item database.BulkItem) {
+		// Notify subscribers about items that were not published before
+		if item.BlogPost {
+			if post, err := r.DB.GetBlogPostByID(item.ID); err == nil && post != nil {
+				r.PubSub.Publish(pubsub.TopicBlogPostPublished, post)
+			}
+			return
+		}
+		if mono, err := r.DB.GetMonologueByID(item.ID); err == nil && mono != nil {
+			r.PubSub.Publish(pubsub.TopicMonologuePublished, mono)
+		}
+	})
+}
+
+// BulkUnpublish is the resolver for the bulkUnpublish field.
+func (r *mutationResolver) BulkUnpublish(ctx context.Context, ids []string) (*models.BulkOperationResult, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.BulkIDs(ids); err != nil {
+		return nil, err
+	}
+	return r.bulkOperation(ids, r.DB.BulkUnpublish, nil)
+}
+
+// BulkDelete is the resolver for the bulkDelete field.
+func (r *mutationResolver) BulkDelete(ctx context.Context, ids []string) (*models.BulkOperationResult, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.BulkIDs(ids); err != nil {
+		return nil, err
+	}
+	return r.bulkOperation(ids, r.DB.BulkDelete, nil)
+}
+
+// BulkAddTags is the resolver for the bulkAddTags field.
+func (r *mutationResolver) BulkAddTags(ctx context.Context, ids []string, tags []string) (*models.BulkOperationResult, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.BulkTags(ids, tags); err != nil {
+		return nil, err
+	}
+	return r.bulkOperation(ids, func(items []database.BulkItem) ([]database.BulkResult, error) {
+		return r.DB.BulkAddTags(items, tags)
+	}, nil)
+}
+
+// BulkRemoveTags is the resolver for the bulkRemoveTags field.
+func (r *mutationResolver) BulkRemoveTags(ctx context.Context, ids []string, tags []string) (*models.BulkOperationResult, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.BulkTags(ids, tags); err != nil {
+		return nil, err
+	}
+	return r.bulkOperation(ids, func(items []database.BulkItem) ([]database.BulkResult, error) {
+		return r.DB.BulkRemoveTags(items, tags)
+	}, nil)
+}
+
+// CreateProfile is the resolver for the createProfile field.
+func (r *mutationResolver) CreateProfile(ctx context.Context, input models.CreateProfileInput) (*models.Profile, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.CreateProfile(input); err != nil {
+		return nil, err
+	}
+	return r.DB.CreateProfile(input)
+}
+
+// UpdateProfile is the resolver for the updateProfile field.
+func (r *mutationResolver) UpdateProfile(ctx context.Context, id string, input models.UpdateProfileInput) (*models.Profile, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeProfile)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.UpdateProfile(input); err != nil {
+		return nil, err
+	}
+	return r.DB.UpdateProfile(id, input)
+}
+
+// DeleteProfile is the resolver for the deleteProfile field.
+func (r *mutationResolver) DeleteProfile(ctx context.Context, id string) (bool, error) {
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	id, err := relay.LocalID(id, relay.TypeProfile)
+	if err != nil {
+		return false, err
+	}
+	return r.DB.DeleteProfile(id)
+}
+
+// CreateSocialLink is the resolver for the createSocialLink field.
+func (r *mutationResolver) CreateSocialLink(ctx context.Context, profileID string, input models.CreateSocialLinkInput) (*models.SocialLink, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.LikeMonologue(id)
+	profileID, err := relay.LocalID(profileID, relay.TypeProfile)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.CreateSocialLink(input); err != nil {
+		return nil, err
+	}
+	return r.DB.CreateSocialLink(profileID, input)
 }
 
-// LikeBlogPost is the resolver for the likeBlogPost field.
-func (r *mutationResolver) LikeBlogPost(ctx context.Context, id string) (*models.LikeResponse, error) {
+// UpdateSocialLink is the resolver for the updateSocialLink field.
+func (r *mutationResolver) UpdateSocialLink(ctx context.Context, id string, input models.UpdateSocialLinkInput) (*models.SocialLink, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.LikeBlogPost(id)
+	if err := validation.UpdateSocialLink(input); err != nil {
+		return nil, err
+	}
+	return r.DB.UpdateSocialLink(id, input)
 }
 
-// GenerateURLPreview is the resolver for the generateUrlPreview field.
-func (r *mutationResolver) GenerateURLPreview(ctx context.Context, url string) (*models.URLPreview, error) {
-	// For now, create a simple preview
-	// In a real implementation, this would fetch the URL and extract metadata
-	return &models.URLPreview{
-		Title:       "Generated Preview",
-		Description: stringPtr("This is a generated preview for " + url),
-		ImageURL:    stringPtr("https://via.placeholder.com/400x200"),
-		SiteName:    stringPtr("Example Site"),
-		URL:         url,
-		Favicon:     stringPtr("https://via.placeholder.com/32x32"),
-		CreatedAt:   time.Now(),
-	}, nil
+// DeleteSocialLink is the resolver for the deleteSocialLink field.
+func (r *mutationResolver) DeleteSocialLink(ctx context.Context, id string) (bool, error) {
+	if r.DB == nil {
+		return false, fmt.Errorf("database connection not available")
+	}
+	return r.DB.DeleteSocialLink(id)
 }
 
-// CreateBlogPost is the resolver for the createBlogPost field.
-func (r *mutationResolver) CreateBlogPost(ctx context.Context, input models.CreateBlogPostInput) (*models.BlogPost, error) {
+// CreateSkill is the resolver for the createSkill field.
+func (r *mutationResolver) CreateSkill(ctx context.Context, input models.CreateSkillInput) (*models.Skill, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.CreateBlogPost(input)
+	if err := validation.CreateSkill(input); err != nil {
+		return nil, err
+	}
+	return r.DB.CreateSkill(input)
 }
 
-// UpdateBlogPost is the resolver for the updateBlogPost field.
-func (r *mutationResolver) UpdateBlogPost(ctx context.Context, id string, input models.UpdateBlogPostInput) (*models.BlogPost, error) {
+// UpdateSkill is the resolver for the updateSkill field.
+func (r *mutationResolver) UpdateSkill(ctx context.Context, id string, input models.UpdateSkillInput) (*models.Skill, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.UpdateBlogPost(id, input)
+	id, err := relay.LocalID(id, relay.TypeSkill)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.UpdateSkill(input); err != nil {
+		return nil, err
+	}
+	return r.DB.UpdateSkill(id, input)
 }
 
-// DeleteBlogPost is the resolver for the deleteBlogPost field.
-func (r *mutationResolver) DeleteBlogPost(ctx context.Context, id string) (bool, error) {
+// DeleteSkill is the resolver for the deleteSkill field.
+func (r *mutationResolver) DeleteSkill(ctx context.Context, id string) (bool, error) {
 	if r.DB == nil {
 		return false, fmt.Errorf("database connection not available")
 	}
-	return r.DB.DeleteBlogPost(id)
+	id, err := relay.LocalID(id, relay.TypeSkill)
+	if err != nil {
+		return false, err
+	}
+	return r.DB.DeleteSkill(id)
 }
 
-// PublishBlogPost is the resolver for the publishBlogPost field.
-func (r *mutationResolver) PublishBlogPost(ctx context.Context, id string) (*models.BlogPost, error) {
+// ReorderSkills is the resolver for the reorderSkills field.
+func (r *mutationResolver) ReorderSkills(ctx context.Context, ids []string) ([]*models.Skill, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.PublishBlogPost(id)
-}
 
-// UnpublishBlogPost is the resolver for the unpublishBlogPost field.
-func (r *mutationResolver) UnpublishBlogPost(ctx context.Context, id string) (*models.BlogPost, error) {
-	if r.DB == nil {
-		return nil, fmt.Errorf("database connection not available")
+	localIDs := make([]string, len(ids))
+	for i, id := range ids {
+		localID, err := relay.LocalID(id, relay.TypeSkill)
+		if err != nil {
+			return nil, err
+		}
+		localIDs[i] = localID
 	}
-	return r.DB.UnpublishBlogPost(id)
+	return r.DB.ReorderSkills(localIDs)
 }
 
-// CreateMonologue is the resolver for the createMonologue field.
-func (r *mutationResolver) CreateMonologue(ctx context.Context, input models.CreateMonologueInput) (*models.Monologue, error) {
-	fmt.Printf("[RESOLVER] CreateMonologue called with input: %+v\n", input)
-	fmt.Printf("[RESOLVER] ContentType: %s\n", input.ContentType)
+// CreateExperience is the resolver for the createExperience field.
+func (r *mutationResolver) CreateExperience(ctx context.Context, input models.CreateExperienceInput) (*models.Experience, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	result, err := r.DB.CreateMonologue(input)
-	if err != nil {
-		fmt.Printf("[RESOLVER] CreateMonologue error: %v\n", err)
+	if err := validation.CreateExperience(input); err != nil {
 		return nil, err
 	}
-	fmt.Printf("[RESOLVER] CreateMonologue success: %+v\n", result)
-	return result, nil
+	return r.DB.CreateExperience(input)
 }
 
-// UpdateMonologue is the resolver for the updateMonologue field.
-func (r *mutationResolver) UpdateMonologue(ctx context.Context, id string, input models.UpdateMonologueInput) (*models.Monologue, error) {
+// UpdateExperience is the resolver for the updateExperience field.
+func (r *mutationResolver) UpdateExperience(ctx context.Context, id string, input models.UpdateExperienceInput) (*models.Experience, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.UpdateMonologue(id, input)
+	id, err := relay.LocalID(id, relay.TypeExperience)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.UpdateExperience(input); err != nil {
+		return nil, err
+	}
+	return r.DB.UpdateExperience(id, input)
 }
 
-// DeleteMonologue is the resolver for the deleteMonologue field.
-func (r *mutationResolver) DeleteMonologue(ctx context.Context, id string) (bool, error) {
+// DeleteExperience is the resolver for the deleteExperience field.
+func (r *mutationResolver) DeleteExperience(ctx context.Context, id string) (bool, error) {
 	if r.DB == nil {
 		return false, fmt.Errorf("database connection not available")
 	}
-	return r.DB.DeleteMonologue(id)
+	id, err := relay.LocalID(id, relay.TypeExperience)
+	if err != nil {
+		return false, err
+	}
+	return r.DB.DeleteExperience(id)
 }
 
-// PublishMonologue is the resolver for the publishMonologue field.
-func (r *mutationResolver) PublishMonologue(ctx context.Context, id string) (*models.Monologue, error) {
+// ID is the resolver for the id field.
+func (r *profileResolver) ID(ctx context.Context, obj *models.Profile) (string, error) {
+	return relay.ToGlobalID(relay.TypeProfile, obj.ID), nil
+}
+
+// Node is the resolver for the node field.
+func (r *queryResolver) Node(ctx context.Context, id string) (models.Node, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.PublishMonologue(id)
+	return r.loadNode(ctx, id)
 }
 
-// UnpublishMonologue is the resolver for the unpublishMonologue field.
-func (r *mutationResolver) UnpublishMonologue(ctx context.Context, id string) (*models.Monologue, error) {
+// Nodes is the resolver for the nodes field.
+func (r *queryResolver) Nodes(ctx context.Context, ids []string) ([]models.Node, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.UnpublishMonologue(id)
+
+	// An ID that cannot be loaded leaves its item null with an error for that item,
+	// the other items are still returned
+	nodes := make([]models.Node, len(ids))
+	for i, id := range ids {
+		node, err := r.loadNode(ctx, id)
+		if err != nil {
+			addItemError(ctx, i, err)
+			continue
+		}
+		nodes[i] = node
+	}
+
+	return nodes, nil
 }
 
 // Query resolvers
@@ -205,26 +1356,54 @@ func (r *queryResolver) Experiences(ctx context.Context) ([]*models.Experience,
 }
 
 // Monologue is the resolver for the monologue field.
-func (r *queryResolver) Monologue(ctx context.Context, id string) (*models.Monologue, error) {
+func (r *queryResolver) Monologue(ctx context.Context, id string, previewToken *string) (*models.Monologue, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.GetMonologueByID(id)
+	id, err := relay.LocalID(id, relay.TypeMonologue)
+	if err != nil {
+		return nil, err
+	}
+	mono, err := r.DB.GetMonologueByID(id)
+	if err != nil || mono == nil || mono.IsPublished {
+		return mono, err
+	}
+
+	// Unpublished monologues are only visible through a preview link
+	if previewToken == nil {
+		return nil, nil
+	}
+	if err := checkPreviewToken(*previewToken, relay.TypeMonologue, id); err != nil {
+		return nil, err
+	}
+	return mono, nil
 }
 
 // Monologues is the resolver for the monologues field.
-func (r *queryResolver) Monologues(ctx context.Context, limit *int, offset *int, tags []string) (*models.MonologuesResponse, error) {
+func (r *queryResolver) Monologues(ctx context.Context, limit *int, offset *int, tags []string, categoryID *string, difficulty *models.Difficulty, rootsOnly *bool) (*models.MonologuesResponse, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
 
-	monologues, err := r.DB.GetMonologues(limit, offset, tags)
+	if categoryID != nil {
+		localID, err := relay.LocalID(*categoryID, relay.TypeCodeCategory)
+		if err != nil {
+			return nil, err
+		}
+		categoryID = &localID
+	}
+	// Any spelling of a tag matches, like the tag queries
+	if len(tags) > 0 {
+		tags = tagSlugs(tags...)
+	}
+
+	monologues, err := r.DB.GetMonologues(limit, offset, categoryID, tags, difficulty, rootsOnly != nil && *rootsOnly)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get total count for pagination
-	allMonologues, err := r.DB.GetMonologues(nil, nil, tags)
+	allMonologues, err := r.DB.GetMonologues(nil, nil, categoryID, tags, difficulty, rootsOnly != nil && *rootsOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -241,28 +1420,166 @@ func (r *queryResolver) Monologues(ctx context.Context, limit *int, offset *int,
 	}, nil
 }
 
+// Thread is the resolver for the thread field.
+func (r *queryResolver) Thread(ctx context.Context, rootID string) ([]*models.Monologue, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	rootID, err := relay.LocalID(rootID, relay.TypeMonologue)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := r.DB.GetThread(rootID)
+	if err != nil {
+		return nil, err
+	}
+	if thread == nil {
+		return []*models.Monologue{}, nil
+	}
+	return thread, nil
+}
+
 // BlogPost is the resolver for the blogPost field.
-func (r *queryResolver) BlogPost(ctx context.Context, slug string) (*models.BlogPost, error) {
+func (r *queryResolver) BlogPost(ctx context.Context, slug string, previewToken *string, locale *string) (*models.BlogPost, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.GetBlogPostBySlug(slug)
+	post, err := r.blogPostBySlug(slug, previewToken)
+	if err != nil || post == nil {
+		return post, err
+	}
+	return post, r.localizeBlogPosts(locale, post)
 }
 
 // BlogPostByID is the resolver for the blogPostByID field.
-func (r *queryResolver) BlogPostByID(ctx context.Context, id string) (*models.BlogPost, error) {
+func (r *queryResolver) BlogPostByID(ctx context.Context, id string, locale *string) (*models.BlogPost, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.GetBlogPostByID(id)
+	id, err := relay.LocalID(id, relay.TypeBlogPost)
+	if err != nil {
+		return nil, err
+	}
+	post, err := r.DB.GetBlogPostByID(id)
+	if err != nil || post == nil {
+		return post, err
+	}
+	// Drafts are only reachable by ID for admins; others need a preview link
+	if post.Status == models.BlogStatusDraft && !auth.IsAdmin(ctx) {
+		return nil, nil
+	}
+	return post, r.localizeBlogPosts(locale, post)
 }
 
 // BlogPosts is the resolver for the blogPosts field.
-func (r *queryResolver) BlogPosts(ctx context.Context) ([]*models.BlogPost, error) {
+func (r *queryResolver) BlogPosts(ctx context.Context, locale *string) ([]*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	posts, err := r.DB.GetBlogPosts()
+	if err != nil {
+		return nil, err
+	}
+	return posts, r.localizeBlogPosts(locale, posts...)
+}
+
+// Tags is the resolver for the tags field.
+func (r *queryResolver) Tags(ctx context.Context) ([]*models.Tag, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetTags()
+}
+
+// Tag is the resolver for the tag field.
+func (r *queryResolver) Tag(ctx context.Context, slug string) (*models.Tag, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetTag(tagSlugs(slug)[0])
+}
+
+// ContentArchive is the resolver for the contentArchive field.
+func (r *queryResolver) ContentArchive(ctx context.Context, year *int, month *int, limit *int, offset *int) (*models.ContentArchive, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.ContentArchive(year, month, limit, offset); err != nil {
+		return nil, err
+	}
+
+	counts, err := r.DB.GetArchiveCounts()
+	if err != nil {
+		return nil, err
+	}
+	archive := &models.ContentArchive{
+		Years: archiveYears(counts),
+		Items: []*models.ArchiveItem{},
+	}
+	if year == nil {
+		return archive, nil
+	}
+
+	from := time.Date(*year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+	if month != nil {
+		from = time.Date(*year, time.Month(*month), 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(0, 1, 0)
+	}
+	for _, count := range counts {
+		if count.Year == *year && (month == nil || count.Month == *month) {
+			archive.TotalCount += count.Count
+		}
+	}
+
+	pageSize, skip := 20, 0
+	if limit != nil {
+		pageSize = *limit
+	}
+	if offset != nil {
+		skip = *offset
+	}
+	entries, err := r.DB.GetArchiveEntries(from, to, pageSize, skip)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		archive.Items = append(archive.Items, r.archiveItem(entry))
+	}
+	archive.HasNextPage = skip+pageSize < archive.TotalCount
+	return archive, nil
+}
+
+// CodeCategories is the resolver for the codeCategories field.
+func (r *queryResolver) CodeCategories(ctx context.Context) ([]*models.CodeCategory, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetCodeCategories()
+}
+
+// CodeCategory is the resolver for the codeCategory field.
+func (r *queryResolver) CodeCategory(ctx context.Context, slug string) (*models.CodeCategory, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetCodeCategoryBySlug(slug)
+}
+
+// Series is the resolver for the series field.
+func (r *queryResolver) Series(ctx context.Context, slug string) (*models.Series, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.DB.GetSeriesBySlug(slug)
+}
+
+// SeriesList is the resolver for the seriesList field.
+func (r *queryResolver) SeriesList(ctx context.Context) ([]*models.Series, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
-	return r.DB.GetBlogPosts()
+	return r.DB.GetSeriesList()
 }
 
 // AdminBlogPosts is the resolver for the adminBlogPosts field.
@@ -281,104 +1598,176 @@ func (r *queryResolver) AdminMonologues(ctx context.Context) ([]*models.Monologu
 	return r.DB.GetAdminMonologues()
 }
 
-// RelatedContent is the resolver for the relatedContent field.
-func (r *queryResolver) RelatedContent(ctx context.Context, monologueID string, limit *int) ([]*models.RelatedContent, error) {
+// AdminScheduledContent is the resolver for the adminScheduledContent field.
+func (r *queryResolver) AdminScheduledContent(ctx context.Context) ([]*models.ScheduledContent, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	posts, err := r.DB.GetScheduledBlogPosts()
+	if err != nil {
+		return nil, err
+	}
+	monologues, err := r.DB.GetScheduledMonologues()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*models.ScheduledContent, 0, len(posts)+len(monologues))
+	for _, post := range posts {
+		items = append(items, scheduledBlogPost(post))
+	}
+	for _, mono := range monologues {
+		items = append(items, r.scheduledMonologue(mono))
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, _ := time.Parse(time.RFC3339Nano, items[i].ScheduledAt)
+		b, _ := time.Parse(time.RFC3339Nano, items[j].ScheduledAt)
+		return a.Before(b)
+	})
+	return items, nil
+}
+
+// Media is the resolver for the media field.
+func (r *queryResolver) Media(ctx context.Context, limit *int, offset *int) ([]*models.Media, error) {
 	if r.DB == nil {
 		return nil, fmt.Errorf("database connection not available")
 	}
 
-	// Get the current monologue
-	currentMonologue, err := r.DB.GetMonologueByID(monologueID)
-	if err != nil || currentMonologue == nil {
-		return []*models.RelatedContent{}, nil
+	pageSize, skip := 50, 0
+	if limit != nil && *limit > 0 {
+		pageSize = min(*limit, 100)
+	}
+	if offset != nil && *offset > 0 {
+		skip = *offset
+	}
+	return r.DB.GetMediaList(pageSize, skip)
+}
+
+// RelatedContent is the resolver for the relatedContent field.
+func (r *queryResolver) RelatedContent(ctx context.Context, monologueID *string, blogPostID *string, limit *int) ([]*models.RelatedContent, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if err := validation.RelatedContent(monologueID, blogPostID, limit); err != nil {
+		return nil, err
 	}
 
-	// Get all published monologues and blog posts
-	allMonos, err := r.DB.GetMonologues(nil, nil, nil)
+	var source database.BulkItem
+	var err error
+	if blogPostID != nil {
+		source.BlogPost = true
+		source.ID, err = relay.LocalID(*blogPostID, relay.TypeBlogPost)
+	} else {
+		source.ID, err = relay.LocalID(*monologueID, relay.TypeMonologue)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	allPosts, err := r.DB.GetBlogPosts()
+	n := 6
+	if limit != nil {
+		n = *limit
+	}
+	entries, err := r.DB.GetRelatedContent(source, n)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]*models.RelatedContent, 0)
-	maxLimit := 6
-	if limit != nil && *limit < maxLimit {
-		maxLimit = *limit
+	result := make([]*models.RelatedContent, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, r.relatedContent(entry))
 	}
+	return result, nil
+}
 
-	// Find related monologues by tag similarity
-	for _, m := range allMonos {
-		if m.ID == monologueID || !m.IsPublished || len(result) >= maxLimit {
-			continue
-		}
+// ID is the resolver for the id field.
+func (r *seriesResolver) ID(ctx context.Context, obj *models.Series) (string, error) {
+	return relay.ToGlobalID(relay.TypeSeries, obj.ID), nil
+}
 
-		// Check for tag overlap
-		hasCommonTag := false
-		for _, tag1 := range currentMonologue.Tags {
-			for _, tag2 := range m.Tags {
-				if tag1 == tag2 {
-					hasCommonTag = true
-					break
-				}
-			}
-			if hasCommonTag {
-				break
-			}
-		}
+// Items is the resolver for the items field.
+func (r *seriesResolver) Items(ctx context.Context, obj *models.Series) ([]*models.SeriesItem, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	return r.seriesItems(obj.ID, false)
+}
 
-		if hasCommonTag {
-			titleLen := min(50, len(m.Content))
-			excerptLen := min(100, len(m.Content))
-			result = append(result, &models.RelatedContent{
-				ID:          m.ID,
-				Title:       m.Content[:titleLen] + "...",
-				Type:        models.ContentTypePost,
-				Excerpt:     stringPtr(m.Content[:excerptLen] + "..."),
-				Tags:        m.Tags,
-				PublishedAt: *m.PublishedAt,
-				ReadTime:    intPtr(len(m.Content)/200 + 1), // Estimated read time based on content length
-			})
-		}
+// AdminItems is the resolver for the adminItems field.
+func (r *seriesResolver) AdminItems(ctx context.Context, obj *models.Series) ([]*models.SeriesItem, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
 	}
+	return r.seriesItems(obj.ID, true)
+}
 
-	// Add related blog posts
-	for _, post := range allPosts {
-		if len(result) >= maxLimit {
-			break
-		}
+// CreatedAt is the resolver for the createdAt field.
+func (r *seriesResolver) CreatedAt(ctx context.Context, obj *models.Series) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
 
-		// Check for tag overlap with blog posts
-		hasCommonTag := false
-		for _, tag1 := range currentMonologue.Tags {
-			for _, tag2 := range post.Tags {
-				if tag1 == tag2 {
-					hasCommonTag = true
-					break
-				}
-			}
-			if hasCommonTag {
-				break
-			}
-		}
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *seriesResolver) UpdatedAt(ctx context.Context, obj *models.Series) (string, error) {
+	return obj.UpdatedAt.Format(time.RFC3339), nil
+}
 
-		if hasCommonTag && post.Status == models.BlogStatusPublished {
-			result = append(result, &models.RelatedContent{
-				ID:          post.ID,
-				Title:       post.Title,
-				Type:        models.ContentTypePost,
-				Excerpt:     post.Excerpt,
-				Tags:        post.Tags,
-				PublishedAt: *post.PublishedAt,
-				ReadTime:    intPtr(len(post.Content)/200 + 1), // Estimated read time based on content length
-			})
+// ID is the resolver for the id field.
+func (r *skillResolver) ID(ctx context.Context, obj *models.Skill) (string, error) {
+	return relay.ToGlobalID(relay.TypeSkill, obj.ID), nil
+}
+
+// LikeCountChanged is the resolver for the likeCountChanged field.
+func (r *subscriptionResolver) LikeCountChanged(ctx context.Context, id string) (<-chan *models.LikeResponse, error) {
+	if r.PubSub == nil {
+		return nil, fmt.Errorf("subscriptions not available")
+	}
+	if !relay.IsUUID(id) {
+		_, localID, err := relay.FromGlobalID(id)
+		if err != nil {
+			return nil, err
 		}
+		id = localID
+	}
+	return pubsub.Subscribe[*models.LikeResponse](ctx, r.PubSub, pubsub.LikeCountTopic(id)), nil
+}
+
+// MonologuePublished is the resolver for the monologuePublished field.
+func (r *subscriptionResolver) MonologuePublished(ctx context.Context) (<-chan *models.Monologue, error) {
+	if r.PubSub == nil {
+		return nil, fmt.Errorf("subscriptions not available")
 	}
+	return pubsub.Subscribe[*models.Monologue](ctx, r.PubSub, pubsub.TopicMonologuePublished), nil
+}
 
-	return result, nil
+// BlogPostPublished is the resolver for the blogPostPublished field.
+func (r *subscriptionResolver) BlogPostPublished(ctx context.Context) (<-chan *models.BlogPost, error) {
+	if r.PubSub == nil {
+		return nil, fmt.Errorf("subscriptions not available")
+	}
+	return pubsub.Subscribe[*models.BlogPost](ctx, r.PubSub, pubsub.TopicBlogPostPublished), nil
+}
+
+// BlogPosts is the resolver for the blogPosts field.
+func (r *tagResolver) BlogPosts(ctx context.Context, obj *models.Tag) ([]*models.BlogPost, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if len(obj.Variants) == 0 {
+		return []*models.BlogPost{}, nil
+	}
+	return r.DB.GetBlogPostsByTag(obj)
+}
+
+// Monologues is the resolver for the monologues field.
+func (r *tagResolver) Monologues(ctx context.Context, obj *models.Tag) ([]*models.Monologue, error) {
+	if r.DB == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if len(obj.Variants) == 0 {
+		return []*models.Monologue{}, nil
+	}
+	return r.DB.GetMonologuesByTag(obj)
 }
 
 // URL Preview resolver
@@ -389,22 +1778,70 @@ func (r *urlPreviewResolver) CreatedAt(ctx context.Context, obj *models.URLPrevi
 // BlogPost returns generated.BlogPostResolver implementation.
 func (r *Resolver) BlogPost() generated.BlogPostResolver { return &blogPostResolver{r} }
 
+// BlogPostTranslation returns generated.BlogPostTranslationResolver implementation.
+func (r *Resolver) BlogPostTranslation() generated.BlogPostTranslationResolver {
+	return &blogPostTranslationResolver{r}
+}
+
+// CodeCategory returns generated.CodeCategoryResolver implementation.
+func (r *Resolver) CodeCategory() generated.CodeCategoryResolver { return &codeCategoryResolver{r} }
+
+// Experience returns generated.ExperienceResolver implementation.
+func (r *Resolver) Experience() generated.ExperienceResolver { return &experienceResolver{r} }
+
+// Media returns generated.MediaResolver implementation.
+func (r *Resolver) Media() generated.MediaResolver { return &mediaResolver{r} }
+
+// MediaVariant returns generated.MediaVariantResolver implementation.
+func (r *Resolver) MediaVariant() generated.MediaVariantResolver { return &mediaVariantResolver{r} }
+
 // Monologue returns generated.MonologueResolver implementation.
 func (r *Resolver) Monologue() generated.MonologueResolver { return &monologueResolver{r} }
 
+// MonologueImage returns generated.MonologueImageResolver implementation.
+func (r *Resolver) MonologueImage() generated.MonologueImageResolver {
+	return &monologueImageResolver{r}
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
+// Profile returns generated.ProfileResolver implementation.
+func (r *Resolver) Profile() generated.ProfileResolver { return &profileResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Series returns generated.SeriesResolver implementation.
+func (r *Resolver) Series() generated.SeriesResolver { return &seriesResolver{r} }
+
+// Skill returns generated.SkillResolver implementation.
+func (r *Resolver) Skill() generated.SkillResolver { return &skillResolver{r} }
+
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
+// Tag returns generated.TagResolver implementation.
+func (r *Resolver) Tag() generated.TagResolver { return &tagResolver{r} }
+
 // UrlPreview returns generated.UrlPreviewResolver implementation.
 func (r *Resolver) UrlPreview() generated.UrlPreviewResolver { return &urlPreviewResolver{r} }
 
 type blogPostResolver struct{ *Resolver }
+type blogPostTranslationResolver struct{ *Resolver }
+type codeCategoryResolver struct{ *Resolver }
+type experienceResolver struct{ *Resolver }
+type mediaResolver struct{ *Resolver }
+type mediaVariantResolver struct{ *Resolver }
 type monologueResolver struct{ *Resolver }
+type monologueImageResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type profileResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type seriesResolver struct{ *Resolver }
+type skillResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
+type tagResolver struct{ *Resolver }
 type urlPreviewResolver struct{ *Resolver }
 
 // !!! WARNING !!!
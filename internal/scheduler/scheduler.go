@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Defaults for New
+const (
+	// DefaultInterval is how often due content is looked for
+	DefaultInterval = 30 * time.Second
+	// DefaultRefreshInterval is how often Refresher is run
+	DefaultRefreshInterval = 5 * time.Minute
+)
+
+// Store publishes the content whose scheduled time has passed. Implementations must make
+// sure every item is returned by exactly one call, even across processes.
+type Store interface {
+	PublishDueContent() (blogPostIDs, monologueIDs []string, err error)
+}
+
+// Refresher renews cached data that expired, such as URL previews, so reads never have
+// to fetch it themselves
+type Refresher interface {
+	RefreshExpired(ctx context.Context) error
+}
+
+// Scheduler periodically publishes scheduled blog posts and monologues and, if Refresher
+// is set, refreshes expired cached data
+type Scheduler struct {
+	Store    Store
+	Interval time.Duration
+	// OnPublished is called with the IDs this process published
+	OnPublished func(blogPostIDs, monologueIDs []string)
+
+	Refresher       Refresher
+	RefreshInterval time.Duration
+}
+
+func New(store Store, onPublished func(blogPostIDs, monologueIDs []string)) *Scheduler {
+	return &Scheduler{
+		Store:           store,
+		Interval:        DefaultInterval,
+		OnPublished:     onPublished,
+		RefreshInterval: DefaultRefreshInterval,
+	}
+}
+
+// Run publishes due content once right away and then every Interval until ctx is done.
+// Refresher runs on its own ticker so slow fetches never delay publishing.
+func (s *Scheduler) Run(ctx context.Context) {
+	if s.Refresher != nil {
+		go s.runRefresher(ctx)
+	}
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce() {
+	blogPostIDs, monologueIDs, err := s.Store.PublishDueContent()
+	if err != nil {
+		fmt.Printf("[SCHEDULER] Failed to publish scheduled content: %v\n", err)
+		return
+	}
+	if len(blogPostIDs) == 0 && len(monologueIDs) == 0 {
+		return
+	}
+
+	fmt.Printf("[SCHEDULER] Published %d blog posts and %d monologues\n", len(blogPostIDs), len(monologueIDs))
+	if s.OnPublished != nil {
+		s.OnPublished(blogPostIDs, monologueIDs)
+	}
+}
+
+func (s *Scheduler) runRefresher(ctx context.Context) {
+	ticker := time.NewTicker(s.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresher.RefreshExpired(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("[SCHEDULER] Failed to refresh expired data: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
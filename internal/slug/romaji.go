@@ -0,0 +1,104 @@
+package slug
+
+import (
+	"strings"
+)
+
+// kana maps hiragana to modified Hepburn romanization
+var kana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'ゔ': "vu",
+}
+
+// smallVowels replace the vowel of the preceding syllable, as in ファ (fa) or ティ (ti)
+var smallVowels = map[rune]string{
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+}
+
+// smallY combine with a preceding i-row syllable, as in きゃ (kya) or しょ (sho)
+var smallY = map[rune]string{
+	'ゃ': "a", 'ゅ': "u", 'ょ': "o",
+}
+
+// toHiragana maps katakana to the hiragana with the same reading
+func toHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - 0x60
+	}
+	return r
+}
+
+// romanize converts a run of hiragana or katakana to lowercase ASCII
+func romanize(s string) string {
+	var syllables []string
+	doubleNext := false
+
+	for _, r := range s {
+		r = toHiragana(r)
+		last := len(syllables) - 1
+
+		switch {
+		case r == 'っ':
+			doubleNext = true
+			continue
+		case r == 'ー':
+			// Long vowels are dropped, as is common in URLs ("サーバー" -> "saba")
+			continue
+		case smallY[r] != "":
+			vowel := smallY[r]
+			if last >= 0 && strings.HasSuffix(syllables[last], "i") && len(syllables[last]) > 1 {
+				prev := strings.TrimSuffix(syllables[last], "i")
+				if strings.HasSuffix(prev, "sh") || strings.HasSuffix(prev, "ch") || strings.HasSuffix(prev, "j") {
+					syllables[last] = prev + vowel
+				} else {
+					syllables[last] = prev + "y" + vowel
+				}
+				continue
+			}
+			syllables = append(syllables, "y"+vowel)
+		case smallVowels[r] != "":
+			vowel := smallVowels[r]
+			if last >= 0 {
+				switch prev := syllables[last]; {
+				case prev == "u":
+					syllables[last] = "w" + vowel
+					continue
+				case prev == "i":
+					syllables[last] = "y" + vowel
+					continue
+				case len(prev) > 1:
+					syllables[last] = prev[:len(prev)-1] + vowel
+					continue
+				}
+			}
+			syllables = append(syllables, vowel)
+		case kana[r] != "":
+			syllable := kana[r]
+			if doubleNext {
+				if strings.HasPrefix(syllable, "ch") {
+					syllable = "t" + syllable
+				} else if c := syllable[0]; !strings.ContainsRune("aiueon", rune(c)) {
+					syllable = string(c) + syllable
+				}
+			}
+			syllables = append(syllables, syllable)
+		}
+		doubleNext = false
+	}
+
+	return strings.Join(syllables, "")
+}
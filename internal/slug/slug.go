@@ -0,0 +1,160 @@
+package slug
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength is the longest slug Generate returns, in characters
+const MaxLength = 60
+
+// Generate derives a URL slug from a title. The result only depends on the title, so the
+// same title always yields the same slug.
+//
+// Latin text is lowercased with accents removed and kana is romanized. Kanji cannot be
+// romanized without a dictionary, so for titles containing kanji only the Latin and
+// katakana words are kept and a short ID derived from the title is appended to keep the
+// slug distinctive. Titles with no usable characters get "post-" and the short ID.
+func Generate(title string) string {
+	title = norm.NFKC.String(strings.TrimSpace(title))
+	hasHan := strings.IndexFunc(title, func(r rune) bool { return unicode.Is(unicode.Han, r) }) >= 0
+
+	var words []string
+	for _, run := range splitScripts(title) {
+		var word string
+		switch run.script {
+		case scriptLatin:
+			word = foldLatin(run.text)
+		case scriptKatakana:
+			word = romanize(run.text)
+		case scriptHiragana:
+			// In kanji titles hiragana is mostly particles and verb endings, which are
+			// meaningless once the kanji around them are gone
+			if !hasHan {
+				word = romanize(run.text)
+			}
+		}
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	slug := truncate(strings.Join(words, "-"), MaxLength)
+	switch {
+	case slug == "":
+		return "post-" + ShortID(title)
+	case hasHan:
+		return truncate(slug, MaxLength-7) + "-" + ShortID(title)
+	default:
+		return slug
+	}
+}
+
+// WithSuffix returns the n-th alternative for a slug that is already taken, e.g. "go-tips-2"
+func WithSuffix(slug string, n int) string {
+	suffix := fmt.Sprintf("-%d", n)
+	return truncate(slug, MaxLength-len(suffix)) + suffix
+}
+
+// ShortID is a six character base-36 hash of s
+func ShortID(s string) string {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	id := strconv.FormatUint(uint64(h.Sum32()%2176782336), 36) // 36^6
+	return strings.Repeat("0", 6-len(id)) + id
+}
+
+type script int
+
+const (
+	scriptOther script = iota
+	scriptLatin
+	scriptHiragana
+	scriptKatakana
+	scriptHan
+)
+
+type scriptRun struct {
+	script script
+	text   string
+}
+
+func scriptOf(r rune) script {
+	switch {
+	case unicode.Is(unicode.Hiragana, r):
+		return scriptHiragana
+	case unicode.Is(unicode.Katakana, r) || r == 'ー':
+		return scriptKatakana
+	case unicode.Is(unicode.Han, r) || r == '々':
+		return scriptHan
+	case r < unicode.MaxLatin1 && (unicode.IsLetter(r) || unicode.IsDigit(r)),
+		unicode.Is(unicode.Latin, r):
+		return scriptLatin
+	default:
+		return scriptOther
+	}
+}
+
+// splitScripts splits s into runs of a single script, dropping spaces and punctuation.
+// Each run becomes a separate word of the slug.
+func splitScripts(s string) []scriptRun {
+	var runs []scriptRun
+	var current strings.Builder
+	currentScript := scriptOther
+
+	flush := func() {
+		if current.Len() > 0 && currentScript != scriptOther {
+			runs = append(runs, scriptRun{script: currentScript, text: current.String()})
+		}
+		current.Reset()
+	}
+
+	for _, r := range s {
+		sc := scriptOf(r)
+		if sc != currentScript {
+			flush()
+			currentScript = sc
+		}
+		if sc != scriptOther {
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return runs
+}
+
+// foldLatin lowercases s and removes accents ("Café" -> "cafe")
+func foldLatin(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		case r == 'ß':
+			sb.WriteString("ss")
+		case r == 'æ':
+			sb.WriteString("ae")
+		case r == 'ø':
+			sb.WriteRune('o')
+		}
+	}
+	return sb.String()
+}
+
+// truncate shortens an ASCII slug to at most maxLen characters, preferring to cut at a hyphen
+func truncate(slug string, maxLen int) string {
+	if len(slug) <= maxLen {
+		return slug
+	}
+	slug = slug[:maxLen]
+	if i := strings.LastIndexByte(slug, '-'); i > maxLen/2 {
+		slug = slug[:i]
+	}
+	return strings.Trim(slug, "-")
+}
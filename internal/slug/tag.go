@@ -0,0 +1,33 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Tag derives the key tags are grouped by, so spellings that only differ in case, width
+// or spacing ("Go", "go", "ｇｏ") are the same tag. Unlike Generate it keeps non-Latin
+// scripts as they are, since tags are short and must not collide. The characters of
+// names like "C++", "C#" and "Node.js" are kept as well.
+func Tag(name string) string {
+	name = strings.ToLower(norm.NFKC.String(strings.TrimSpace(name)))
+
+	var b strings.Builder
+	dash := false
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r) || r == '+' || r == '#' || r == '.':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		default:
+			dash = true
+		}
+	}
+
+	return b.String()
+}
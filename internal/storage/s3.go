@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// S3Client is the subset of an S3-compatible client that S3 needs. Adapters for the AWS
+// SDK, MinIO or Cloudflare R2 clients only have to forward these two calls.
+type S3Client interface {
+	PutObject(ctx context.Context, bucket, key string, body io.Reader, contentType string) error
+	DeleteObject(ctx context.Context, bucket, key string) error
+}
+
+// S3 stores files in a bucket of an S3-compatible object store. PublicURL is the bucket's
+// public endpoint or CDN, e.g. "https://cdn.example.com".
+type S3 struct {
+	Client    S3Client
+	Bucket    string
+	Prefix    string
+	PublicURL string
+}
+
+func NewS3(client S3Client, bucket, prefix, publicURL string) *S3 {
+	return &S3{
+		Client:    client,
+		Bucket:    bucket,
+		Prefix:    strings.Trim(prefix, "/"),
+		PublicURL: strings.TrimRight(publicURL, "/"),
+	}
+}
+
+func (s *S3) objectKey(key string) string {
+	if s.Prefix == "" {
+		return key
+	}
+	return s.Prefix + "/" + key
+}
+
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	if key == "" || strings.Contains(key, "..") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	if err := s.Client.PutObject(ctx, s.Bucket, s.objectKey(key), body, contentType); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := s.Client.DeleteObject(ctx, s.Bucket, s.objectKey(key)); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3) URL(key string) string {
+	return s.PublicURL + "/" + s.objectKey(key)
+}
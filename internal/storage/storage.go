@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrInvalidKey is returned for keys that are empty or could escape the storage root
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage stores uploaded files under slash-separated keys such as "2025/07/abc.jpg"
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL a stored key is served from
+	URL(key string) string
+}
@@ -0,0 +1,62 @@
+package textutil
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+var md = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// PlainText strips Markdown syntax from source and returns the text a reader sees.
+// Code blocks, raw HTML and images are dropped; blocks are separated by newlines.
+func PlainText(source string) string {
+	src := []byte(source)
+	doc := md.Parser().Parse(text.NewReader(src))
+
+	var sb strings.Builder
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch n := node.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock, *ast.RawHTML, *ast.Image:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			if entering {
+				sb.Write(n.Segment.Value(src))
+				if n.SoftLineBreak() || n.HardLineBreak() {
+					sb.WriteByte(' ')
+				}
+			}
+		case *ast.String:
+			if entering {
+				sb.Write(n.Value)
+			}
+		case *extast.TableCell:
+			if !entering {
+				sb.WriteByte(' ')
+			}
+		default:
+			if !entering && node.Type() == ast.TypeBlock && sb.Len() > 0 {
+				sb.WriteByte('\n')
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return strings.TrimSpace(collapseNewlines(sb.String()))
+}
+
+// collapseNewlines turns the runs of newlines left by nested blocks into a single one
+func collapseNewlines(s string) string {
+	lines := strings.Split(s, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
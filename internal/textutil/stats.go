@@ -0,0 +1,78 @@
+package textutil
+
+import (
+	"unicode"
+)
+
+// Reading speeds used for ReadingMinutes. Japanese readers cover roughly 500 characters
+// a minute while English prose is usually estimated at about 200 words a minute.
+const (
+	CJKCharactersPerMinute = 500
+	WordsPerMinute         = 200
+)
+
+// Stats describes the readable text of a document
+type Stats struct {
+	// Characters counts every non-whitespace character
+	Characters int
+	// CJKCharacters counts Han, Hiragana, Katakana and Hangul characters
+	CJKCharacters int
+	// Words counts runs of non-CJK letters and digits, such as English words or numbers
+	Words int
+}
+
+// Analyze computes the statistics of the Markdown document source, ignoring markup
+// and code blocks
+func Analyze(source string) Stats {
+	return Count(PlainText(source))
+}
+
+// Count computes the statistics of plain text
+func Count(s string) Stats {
+	var stats Stats
+	inWord := false
+
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+		stats.Characters++
+
+		switch {
+		case isCJK(r):
+			stats.CJKCharacters++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				stats.Words++
+			}
+			inWord = true
+		case r == '\'' || r == '-' || r == '’':
+			// keep contractions and hyphenated words together
+		default:
+			inWord = false
+		}
+	}
+
+	return stats
+}
+
+// ReadingMinutes is the estimated reading time, rounded up and at least one minute
+func (s Stats) ReadingMinutes() int {
+	// Work in characters-per-minute units to avoid floating point:
+	// one word weighs CJKCharactersPerMinute/WordsPerMinute characters
+	weighted := s.CJKCharacters*WordsPerMinute + s.Words*CJKCharactersPerMinute
+	perMinute := CJKCharactersPerMinute * WordsPerMinute
+
+	minutes := (weighted + perMinute - 1) / perMinute
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		r == 'ー' // prolonged sound mark is Common script but read as part of katakana words
+}
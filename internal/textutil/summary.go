@@ -0,0 +1,85 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// Ellipsis is appended to text that was cut in the middle of a sentence
+const Ellipsis = "…"
+
+// Summarize returns the beginning of the Markdown document source as plain text of at
+// most maxLen characters (grapheme clusters), for excerpts and meta descriptions
+func Summarize(source string, maxLen int) string {
+	return Truncate(strings.Join(strings.Split(PlainText(source), "\n"), " "), maxLen)
+}
+
+// Title returns the first line of the Markdown document source as plain text of at most
+// maxLen characters, for content that has no title of its own
+func Title(source string, maxLen int) string {
+	firstLine, _, _ := strings.Cut(PlainText(source), "\n")
+	return Truncate(firstLine, maxLen)
+}
+
+// Truncate shortens s to at most maxLen grapheme clusters, so emoji and combining
+// characters are never split. It keeps whole sentences when they fill at least half of
+// maxLen; otherwise it cuts at the last word boundary, or at any grapheme for text
+// without spaces such as Japanese, and appends Ellipsis.
+func Truncate(s string, maxLen int) string {
+	s = strings.TrimSpace(s)
+	if maxLen <= 0 {
+		return ""
+	}
+	if uniseg.GraphemeClusterCount(s) <= maxLen {
+		return s
+	}
+
+	if sentences := leadingSentences(s, maxLen); uniseg.GraphemeClusterCount(sentences) >= maxLen/2 {
+		return sentences
+	}
+
+	// Reserve one cluster for the ellipsis
+	cut := prefixGraphemes(s, maxLen-1)
+	if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 && uniseg.GraphemeClusterCount(cut[:i]) >= maxLen/2 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimRightFunc(cut, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + Ellipsis
+}
+
+// leadingSentences returns as many complete sentences from the start of s as fit in maxLen
+// grapheme clusters
+func leadingSentences(s string, maxLen int) string {
+	var sentence string
+	end, length, state := 0, 0, -1
+	rest := s
+
+	for len(rest) > 0 {
+		sentence, rest, state = uniseg.FirstSentenceInString(rest, state)
+		length += uniseg.GraphemeClusterCount(sentence)
+		if length > maxLen {
+			break
+		}
+		end += len(sentence)
+	}
+
+	return strings.TrimSpace(s[:end])
+}
+
+// prefixGraphemes returns the first n grapheme clusters of s
+func prefixGraphemes(s string, n int) string {
+	end, state := 0, -1
+	rest := s
+
+	for i := 0; i < n && len(rest) > 0; i++ {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		end += len(cluster)
+	}
+
+	return s[:end]
+}
@@ -0,0 +1,219 @@
+package urlpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/outbound"
+)
+
+// Cache lifetimes used by NewService
+const (
+	DefaultTTL         = 24 * time.Hour
+	DefaultStaleTTL    = 7 * 24 * time.Hour
+	DefaultNegativeTTL = time.Hour
+	refreshTimeout     = 30 * time.Second
+	// refreshBatch is how many expired entries RefreshExpired fetches per run
+	refreshBatch = 20
+)
+
+// ErrFetchFailed is returned while a failed fetch is negatively cached
+var ErrFetchFailed = errors.New("url preview unavailable")
+
+// Entry is a cached fetch result. Failures are cached with Err set and Preview nil.
+// FailedAt is when the last fetch failed; a stored preview is kept when a refresh of it
+// fails, so an entry can have both a Preview and a FailedAt.
+type Entry struct {
+	Preview   *models.URLPreview
+	Err       string
+	FetchedAt time.Time
+	ExpiresAt time.Time
+	FailedAt  *time.Time
+}
+
+// Store persists entries by normalized URL. GetURLPreviewEntry returns nil for unknown
+// keys. Saving a failed entry over a stored preview keeps the preview and only records
+// the failure. ExpiredURLPreviewKeys returns up to limit keys of expired entries that
+// did not fail after failedBefore, oldest first.
+type Store interface {
+	GetURLPreviewEntry(key string) (*Entry, error)
+	SaveURLPreviewEntry(key string, entry *Entry) error
+	ExpiredURLPreviewKeys(failedBefore time.Time, limit int) ([]string, error)
+}
+
+// Service serves previews from Store and fetches them when missing or expired. Expired
+// entries are still served for StaleTTL while a background refresh replaces them.
+// Monologues read their stored preview directly from the database; RefreshExpired keeps
+// those fresh without making readers wait for the network.
+type Service struct {
+	Fetcher     *Fetcher
+	Store       Store
+	TTL         time.Duration
+	StaleTTL    time.Duration
+	NegativeTTL time.Duration
+
+	refreshing sync.Map
+}
+
+func NewService(fetcher *Fetcher, store Store) *Service {
+	return &Service{
+		Fetcher:     fetcher,
+		Store:       store,
+		TTL:         DefaultTTL,
+		StaleTTL:    DefaultStaleTTL,
+		NegativeTTL: DefaultNegativeTTL,
+	}
+}
+
+// Get returns the preview for rawURL, fetching it only when nothing usable is cached
+func (s *Service) Get(ctx context.Context, rawURL string) (*models.URLPreview, error) {
+	key, err := Normalize(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.load(key)
+	if err != nil {
+		return nil, err
+	}
+	if preview, ok, err := s.serve(key, rawURL, entry); ok {
+		return preview, err
+	}
+
+	return s.refresh(ctx, key, rawURL)
+}
+
+// Refresh fetches rawURL now, replacing any cached entry
+func (s *Service) Refresh(ctx context.Context, rawURL string) (*models.URLPreview, error) {
+	key, err := Normalize(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return s.refresh(ctx, key, rawURL)
+}
+
+// RefreshExpired fetches expired entries again, a batch per call, leaving out those whose
+// last refresh failed less than NegativeTTL ago. It is run periodically by the scheduler.
+func (s *Service) RefreshExpired(ctx context.Context) error {
+	if s.Store == nil {
+		return nil
+	}
+
+	keys, err := s.Store.ExpiredURLPreviewKeys(time.Now().Add(-s.NegativeTTL), refreshBatch)
+	if err != nil {
+		return fmt.Errorf("failed to list expired url previews: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, running := s.refreshing.LoadOrStore(key, struct{}{}); running {
+			continue
+		}
+
+		fetchCtx, cancel := context.WithTimeout(ctx, refreshTimeout)
+		// The key is the normalized URL, which fetches the same page
+		_, err := s.refresh(fetchCtx, key, key)
+		cancel()
+		s.refreshing.Delete(key)
+		if err == nil {
+			continue
+		}
+
+		fmt.Printf("[PREVIEW] Refresh of %s failed: %v\n", key, err)
+		// refresh does not record refused URLs; without a failure they would come back in
+		// every batch
+		if errors.Is(err, outbound.ErrBlocked) {
+			now := time.Now()
+			failed := &Entry{Err: err.Error(), FetchedAt: now, ExpiresAt: now.Add(s.NegativeTTL), FailedAt: &now}
+			if err := s.Store.SaveURLPreviewEntry(key, failed); err != nil {
+				return fmt.Errorf("failed to record refused url preview: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) load(key string) (*Entry, error) {
+	if s.Store == nil {
+		return nil, nil
+	}
+	entry, err := s.Store.GetURLPreviewEntry(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load url preview: %w", err)
+	}
+	return entry, nil
+}
+
+// serve decides whether entry can answer a request. Fresh entries are served as they
+// are, stale successful ones are served while a refresh runs in the background.
+func (s *Service) serve(key, rawURL string, entry *Entry) (*models.URLPreview, bool, error) {
+	if entry == nil {
+		return nil, false, nil
+	}
+
+	// A URL that just failed is not fetched again before NegativeTTL, whether or not an
+	// older preview of it is stored
+	now := time.Now()
+	recentlyFailed := entry.FailedAt != nil && now.Sub(*entry.FailedAt) < s.NegativeTTL
+	if now.Before(entry.ExpiresAt) || recentlyFailed {
+		if entry.Preview == nil {
+			return nil, true, fmt.Errorf("%w: %s", ErrFetchFailed, entry.Err)
+		}
+		return entry.Preview, true, nil
+	}
+	if entry.Preview != nil && now.Before(entry.ExpiresAt.Add(s.StaleTTL)) {
+		s.refreshInBackground(key, rawURL)
+		return entry.Preview, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (s *Service) refresh(ctx context.Context, key, rawURL string) (*models.URLPreview, error) {
+	now := time.Now()
+	preview, fetchErr := s.Fetcher.Fetch(ctx, rawURL)
+
+	// Refused URLs are not cached: the check is cheap and callers need the specific error
+	if errors.Is(fetchErr, outbound.ErrBlocked) {
+		return nil, fetchErr
+	}
+
+	entry := &Entry{Preview: preview, FetchedAt: now, ExpiresAt: now.Add(s.TTL)}
+	if fetchErr != nil {
+		entry.Err = fetchErr.Error()
+		entry.ExpiresAt = now.Add(s.NegativeTTL)
+		entry.FailedAt = &now
+	}
+
+	if s.Store != nil {
+		if err := s.Store.SaveURLPreviewEntry(key, entry); err != nil {
+			fmt.Printf("[PREVIEW] Failed to cache preview for %s: %v\n", key, err)
+		}
+	}
+
+	return preview, fetchErr
+}
+
+// refreshInBackground fetches key unless a refresh for it is already running
+func (s *Service) refreshInBackground(key, rawURL string) {
+	if _, running := s.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer s.refreshing.Delete(key)
+
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		if _, err := s.refresh(ctx, key, rawURL); err != nil {
+			fmt.Printf("[PREVIEW] Background refresh of %s failed: %v\n", key, err)
+		}
+	}()
+}
@@ -0,0 +1,241 @@
+package urlpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/outbound"
+)
+
+// Defaults for NewFetcher
+const (
+	DefaultTimeout     = 10 * time.Second
+	DefaultMaxBodySize = 1 << 20 // 1 MiB, enough for the <head> of any reasonable page
+	DefaultUserAgent   = "Mozilla/5.0 (compatible; PortfolioPreviewBot/1.0)"
+)
+
+var (
+	// ErrNotHTML is returned for URLs that do not serve an HTML page
+	ErrNotHTML = errors.New("url does not point to an HTML page")
+	// ErrBadStatus is returned for non-2xx responses
+	ErrBadStatus = errors.New("unexpected response status")
+)
+
+// Fetcher downloads pages and extracts their link preview metadata. Client can be
+// replaced, e.g. with an httptest server's client in tests.
+type Fetcher struct {
+	Client      *http.Client
+	MaxBodySize int64
+	UserAgent   string
+}
+
+// NewFetcher returns a fetcher whose client refuses private and internal addresses,
+// since the URLs come from API callers
+func NewFetcher() *Fetcher {
+	return &Fetcher{
+		Client:      outbound.NewClient(outbound.Options{Timeout: DefaultTimeout}),
+		MaxBodySize: DefaultMaxBodySize,
+		UserAgent:   DefaultUserAgent,
+	}
+}
+
+// Fetch downloads rawURL and returns its preview. Only the first MaxBodySize bytes are
+// read, which is where the <head> metadata lives.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*models.URLPreview, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	req.Header.Set("User-Agent", f.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.1")
+	req.Header.Set("Accept-Language", "ja,en;q=0.8")
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %s", ErrBadStatus, resp.Status)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil &&
+		mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("%w: %s", ErrNotHTML, mediaType)
+	}
+
+	// charset.NewReader honours the Content-Type header, a BOM and <meta charset>, so
+	// Shift_JIS and EUC-JP pages are decoded to UTF-8
+	body, err := charset.NewReader(io.LimitReader(resp.Body, f.MaxBodySize), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect charset: %w", err)
+	}
+
+	meta := parseHead(body)
+	return meta.preview(resp.Request.URL), nil
+}
+
+// pageMeta holds the raw values found in a document's <head>
+type pageMeta struct {
+	title    string
+	base     string
+	meta     map[string]string
+	favicons map[string]string
+}
+
+// parseHead tokenizes the document until </head> or <body>, collecting <title>, <base>,
+// <meta> and icon <link> elements
+func parseHead(r io.Reader) *pageMeta {
+	meta := &pageMeta{meta: make(map[string]string), favicons: make(map[string]string)}
+	tokenizer := html.NewTokenizer(r)
+	inTitle := false
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return meta
+		case html.TextToken:
+			if inTitle && meta.title == "" {
+				meta.title = strings.TrimSpace(string(tokenizer.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				return meta
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			attrs := map[string]string{}
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[strings.ToLower(string(key))] = string(value)
+			}
+
+			switch string(name) {
+			case "body":
+				return meta
+			case "title":
+				inTitle = true
+			case "base":
+				if meta.base == "" {
+					meta.base = attrs["href"]
+				}
+			case "meta":
+				key := attrs["property"]
+				if key == "" {
+					key = attrs["name"]
+				}
+				key = strings.ToLower(key)
+				if _, seen := meta.meta[key]; key != "" && !seen {
+					meta.meta[key] = strings.TrimSpace(attrs["content"])
+				}
+			case "link":
+				for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+					if _, seen := meta.favicons[rel]; !seen && attrs["href"] != "" {
+						meta.favicons[rel] = attrs["href"]
+					}
+				}
+			}
+		}
+	}
+}
+
+// first returns the first non-empty meta value among keys
+func (m *pageMeta) first(keys ...string) string {
+	for _, key := range keys {
+		if value := m.meta[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// preview builds the result, preferring Open Graph over Twitter Card over plain HTML and
+// resolving relative URLs against <base> or the final URL after redirects
+func (m *pageMeta) preview(pageURL *url.URL) *models.URLPreview {
+	base := pageURL
+	if m.base != "" {
+		if parsed, err := pageURL.Parse(m.base); err == nil {
+			base = parsed
+		}
+	}
+	resolve := func(ref string) *string {
+		if ref == "" {
+			return nil
+		}
+		parsed, err := base.Parse(ref)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return nil
+		}
+		resolved := parsed.String()
+		return &resolved
+	}
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+
+	title := m.first("og:title", "twitter:title")
+	if title == "" {
+		title = m.title
+	}
+	if title == "" {
+		title = fallbackTitle(pageURL)
+	}
+
+	siteName := m.first("og:site_name", "application-name")
+	if siteName == "" {
+		siteName = pageURL.Hostname()
+	}
+
+	favicon := ""
+	for _, rel := range []string{"icon", "shortcut", "apple-touch-icon"} {
+		if favicon = m.favicons[rel]; favicon != "" {
+			break
+		}
+	}
+	if favicon == "" {
+		favicon = "/favicon.ico"
+	}
+
+	return &models.URLPreview{
+		Title:       title,
+		Description: optional(m.first("og:description", "twitter:description", "description")),
+		ImageURL:    resolve(m.first("og:image:secure_url", "og:image", "og:image:url", "twitter:image", "twitter:image:src")),
+		SiteName:    &siteName,
+		URL:         pageURL.String(),
+		Favicon:     resolve(favicon),
+		CreatedAt:   time.Now(),
+	}
+}
+
+// fallbackTitle names pages without any title after the last path segment or the host
+func fallbackTitle(pageURL *url.URL) string {
+	if name := path.Base(pageURL.Path); name != "/" && name != "." {
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			return unescaped
+		}
+		return name
+	}
+	return pageURL.Hostname()
+}
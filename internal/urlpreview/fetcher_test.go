@@ -0,0 +1,169 @@
+package urlpreview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/outbound"
+)
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	page := func(w http.ResponseWriter, body string) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(body))
+	}
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		page(w, `<html><head><title>Plain title</title>
+			<meta property="og:title" content="OG title">
+			<meta name="description" content="A description">
+			<meta property="og:image" content="/images/cover.png">
+			</head><body><title>Not this</title></body></html>`)
+	})
+	mux.HandleFunc("/articles/final", func(w http.ResponseWriter, r *http.Request) {
+		page(w, `<html><head><title>Final</title><meta property="og:image" content="cover.png"></head></html>`)
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/articles/final", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/older", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/old", http.StatusFound)
+	})
+	mux.HandleFunc("/huge-page", func(w http.ResponseWriter, r *http.Request) {
+		page(w, "<html><head>"+strings.Repeat("<!-- padding -->", 1024)+"<title>Too late</title></head></html>")
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG\r\n\x1a\n"))
+	})
+	mux.HandleFunc("/data.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"title": "json"}`))
+	})
+	mux.HandleFunc("/xhtml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xhtml+xml")
+		w.Write([]byte(`<html><head><title>XHTML</title></head></html>`))
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		wantTitle string
+		wantURL   string
+		wantImage string
+		wantErr   error
+	}{
+		{
+			name:      "open graph wins over the title element",
+			path:      "/page",
+			wantTitle: "OG title",
+			wantURL:   "/page",
+			wantImage: "/images/cover.png",
+		},
+		{
+			name:      "redirect resolves against the final URL",
+			path:      "/old",
+			wantTitle: "Final",
+			wantURL:   "/articles/final",
+			wantImage: "/articles/cover.png",
+		},
+		{
+			name:      "redirect chain",
+			path:      "/older",
+			wantTitle: "Final",
+			wantURL:   "/articles/final",
+			wantImage: "/articles/cover.png",
+		},
+		{
+			name:      "body beyond the size cap is not read",
+			path:      "/huge-page",
+			wantTitle: "huge-page",
+			wantURL:   "/huge-page",
+		},
+		{
+			name:      "xhtml is accepted",
+			path:      "/xhtml",
+			wantTitle: "XHTML",
+			wantURL:   "/xhtml",
+		},
+		{name: "image is not html", path: "/image.png", wantErr: ErrNotHTML},
+		{name: "json is not html", path: "/data.json", wantErr: ErrNotHTML},
+		{name: "error status", path: "/gone", wantErr: ErrBadStatus},
+	}
+
+	fetcher := &Fetcher{
+		Client:      server.Client(),
+		MaxBodySize: 4096,
+		UserAgent:   DefaultUserAgent,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := fetcher.Fetch(context.Background(), server.URL+tt.path)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Fetch() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+
+			if preview.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", preview.Title, tt.wantTitle)
+			}
+			if want := server.URL + tt.wantURL; preview.URL != want {
+				t.Errorf("URL = %q, want %q", preview.URL, want)
+			}
+			var image string
+			if preview.ImageURL != nil {
+				image = strings.TrimPrefix(*preview.ImageURL, server.URL)
+			}
+			if image != tt.wantImage {
+				t.Errorf("ImageURL = %q, want %q", image, tt.wantImage)
+			}
+		})
+	}
+}
+
+func TestFetchBlocksPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request reached the server: %s", r.URL)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "loopback test server", url: server.URL + "/page"},
+		{name: "localhost", url: "http://localhost/"},
+		{name: "loopback", url: "http://127.0.0.1/"},
+		{name: "ipv6 loopback", url: "http://[::1]/"},
+		{name: "private network", url: "http://10.0.0.1/"},
+		{name: "cloud metadata", url: "http://169.254.169.254/latest/meta-data/"},
+		{name: "ipv4-mapped ipv6", url: "http://[::ffff:192.168.0.1]/"},
+		{name: "non-http scheme", url: "ftp://example.com/file"},
+	}
+
+	fetcher := NewFetcher()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fetcher.Fetch(context.Background(), tt.url)
+			if !errors.Is(err, outbound.ErrBlocked) {
+				t.Fatalf("Fetch(%q) error = %v, want %v", tt.url, err, outbound.ErrBlocked)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package urlpreview
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that only identify the campaign or click that led
+// to a page. Links that differ only in these share one preview.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "gbraid": true, "wbraid": true,
+	"msclkid": true, "yclid": true, "twclid": true, "igshid": true, "mc_cid": true,
+	"mc_eid": true, "_ga": true, "_gl": true, "ref_src": true, "ref_url": true,
+	"s_cid": true, "spm": true,
+}
+
+// Normalize returns the cache key for rawURL: lowercase scheme and host, no default
+// port, no fragment, no tracking parameters (utm_* and the ids above) and the remaining
+// query parameters sorted
+func Normalize(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("invalid url: %s is not an absolute http or https URL", rawURL)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+	// Encode sorts by key
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
@@ -0,0 +1,479 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/models"
+	"github.com/naoya0117/portfolio-v2025-api/internal/slug"
+)
+
+// SlugTaken reports whether a blog post other than excludeID already uses slug
+type SlugTaken func(slug, excludeID string) (bool, error)
+
+// Blog post inputs
+func CreateBlogPost(input models.CreateBlogPostInput, slugTaken SlugTaken) error {
+	var errs Errors
+
+	errs.Required("input.title", &input.Title, 500)
+	errs.Slug("input.slug", input.Slug)
+	errs.MaxLength("input.excerpt", input.Excerpt, 1000)
+	errs.Required("input.content", &input.Content, MaxBlogContentLength)
+	errs.URL("input.coverImageUrl", input.CoverImageURL, true)
+	errs.Tags("input.tags", input.Tags)
+	if input.Status != nil && !input.Status.IsValid() {
+		errs.Add("input.status", "%q is not a valid BlogStatus", *input.Status)
+	}
+	errs.MaxLength("input.seoTitle", input.SeoTitle, 500)
+	errs.MaxLength("input.seoDescription", input.SeoDescription, 300)
+	errs.Locale("input.locale", input.Locale)
+
+	if input.Slug != nil {
+		if err := checkSlugTaken(&errs, *input.Slug, "", slugTaken); err != nil {
+			return err
+		}
+	}
+
+	return errs.Err()
+}
+
+func UpdateBlogPost(id string, input models.UpdateBlogPostInput, slugTaken SlugTaken) error {
+	var errs Errors
+
+	errs.Required("input.title", input.Title, 500)
+	errs.Slug("input.slug", input.Slug)
+	errs.MaxLength("input.excerpt", input.Excerpt, 1000)
+	errs.Required("input.content", input.Content, MaxBlogContentLength)
+	errs.URL("input.coverImageUrl", input.CoverImageURL, true)
+	errs.Tags("input.tags", input.Tags)
+	if input.Status != nil && !input.Status.IsValid() {
+		errs.Add("input.status", "%q is not a valid BlogStatus", *input.Status)
+	}
+	errs.MaxLength("input.seoTitle", input.SeoTitle, 500)
+	errs.MaxLength("input.seoDescription", input.SeoDescription, 300)
+	errs.Locale("input.locale", input.Locale)
+
+	if input.Slug != nil {
+		if err := checkSlugTaken(&errs, *input.Slug, id, slugTaken); err != nil {
+			return err
+		}
+	}
+
+	return errs.Err()
+}
+
+// BlogPostTranslation checks a translation with the limits of the post's own fields
+func BlogPostTranslation(input models.BlogPostTranslationInput) error {
+	var errs Errors
+
+	errs.Locale("input.locale", &input.Locale)
+	errs.Required("input.title", &input.Title, 500)
+	errs.MaxLength("input.excerpt", input.Excerpt, 1000)
+	errs.Required("input.content", &input.Content, MaxBlogContentLength)
+	errs.MaxLength("input.seoTitle", input.SeoTitle, 500)
+	errs.MaxLength("input.seoDescription", input.SeoDescription, 300)
+
+	return errs.Err()
+}
+
+// checkSlugTaken only queries the database for well-formed slugs. The returned error
+// is a lookup failure, not a violation.
+func checkSlugTaken(errs *Errors, slug, excludeID string, slugTaken SlugTaken) error {
+	if slugTaken == nil || !slugRegex.MatchString(slug) {
+		return nil
+	}
+
+	taken, err := slugTaken(slug, excludeID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		errs.Add("input.slug", "slug %q is already in use", slug)
+	}
+
+	return nil
+}
+
+// Monologue inputs
+func CreateMonologue(input models.CreateMonologueInput) error {
+	var errs Errors
+
+	errs.Required("input.content", &input.Content, MaxMonologueContentLength)
+	monologueContentType(&errs, "input.contentType", &input.ContentType)
+	errs.CodeLanguage("input.codeLanguage", input.CodeLanguage)
+	errs.MaxLength("input.codeSnippet", input.CodeSnippet, MaxCodeSnippetLength)
+	errs.Tags("input.tags", input.Tags)
+	errs.URL("input.url", input.URL, false)
+	errs.MaxLength("input.series", input.Series, 255)
+	errs.MaxLength("input.category", input.Category, 255)
+	monologueImages(&errs, "input.images", input.Images)
+
+	switch input.ContentType {
+	case models.ContentTypeCode:
+		if input.CodeSnippet == nil || *input.CodeSnippet == "" {
+			errs.Add("input.codeSnippet", "is required for CODE monologues")
+		}
+	case models.ContentTypeURLPreview:
+		if input.URL == nil || *input.URL == "" {
+			errs.Add("input.url", "is required for URL_PREVIEW monologues")
+		}
+	}
+
+	return errs.Err()
+}
+
+func UpdateMonologue(input models.UpdateMonologueInput) error {
+	var errs Errors
+
+	errs.Required("input.content", input.Content, MaxMonologueContentLength)
+	monologueContentType(&errs, "input.contentType", input.ContentType)
+	errs.CodeLanguage("input.codeLanguage", input.CodeLanguage)
+	errs.MaxLength("input.codeSnippet", input.CodeSnippet, MaxCodeSnippetLength)
+	errs.Tags("input.tags", input.Tags)
+	errs.URL("input.url", input.URL, false)
+	errs.MaxLength("input.series", input.Series, 255)
+	errs.MaxLength("input.category", input.Category, 255)
+	if input.Difficulty != nil && input.ClearDifficulty != nil && *input.ClearDifficulty {
+		errs.Add("input.clearDifficulty", "cannot be combined with difficulty")
+	}
+	monologueImages(&errs, "input.images", input.Images)
+
+	return errs.Err()
+}
+
+// Limits of monologue images
+const (
+	MaxMonologueImages = 20
+	MaxCaptionLength   = 1000
+)
+
+func monologueImages(errs *Errors, field string, images []*models.MonologueImageInput) {
+	if len(images) > MaxMonologueImages {
+		errs.Add(field, "must have at most %d items", MaxMonologueImages)
+	}
+
+	for i, image := range images {
+		itemField := fmt.Sprintf("%s[%d]", field, i)
+		if (image.MediaID == nil) == (image.URL == nil) {
+			errs.Add(itemField, "exactly one of mediaId and url must be set")
+		}
+		errs.URL(itemField+".url", image.URL, false)
+		errs.MaxLength(itemField+".url", image.URL, MaxURLLength)
+		errs.MaxLength(itemField+".alt", image.Alt, MaxAltTextLength)
+		errs.MaxLength(itemField+".caption", image.Caption, MaxCaptionLength)
+		if image.Width != nil && *image.Width <= 0 {
+			errs.Add(itemField+".width", "must be positive")
+		}
+		if image.Height != nil && *image.Height <= 0 {
+			errs.Add(itemField+".height", "must be positive")
+		}
+	}
+}
+
+// PublishMonologue reports why a monologue could not be published as a violation of the
+// images field
+func PublishMonologue(err error) error {
+	var errs Errors
+	errs.Add("images", "%s", err.Error())
+	return errs.Err()
+}
+
+// monologueContentType rejects BLOG, which only exists for related content results and
+// is not allowed by the monologues.content_type CHECK constraint
+func monologueContentType(errs *Errors, field string, contentType *models.ContentType) {
+	if contentType == nil {
+		return
+	}
+	if !contentType.IsValid() || *contentType == models.ContentTypeBlog {
+		errs.Add(field, "%q is not a valid monologue content type", *contentType)
+	}
+}
+
+// PreviewURL checks the url argument of generateUrlPreview
+func PreviewURL(previewURL string) error {
+	var errs Errors
+	errs.Required("url", &previewURL, MaxURLLength)
+	errs.URL("url", &previewURL, false)
+	return errs.Err()
+}
+
+// Profile inputs
+func CreateProfile(input models.CreateProfileInput) error {
+	return profile(&input.Name, input.Title, input.AvatarURL)
+}
+
+func UpdateProfile(input models.UpdateProfileInput) error {
+	return profile(input.Name, input.Title, input.AvatarURL)
+}
+
+func profile(name, title, avatarURL *string) error {
+	var errs Errors
+
+	errs.Required("input.name", name, 255)
+	errs.MaxLength("input.title", title, 255)
+	errs.MaxLength("input.avatarUrl", avatarURL, 500)
+	errs.URL("input.avatarUrl", avatarURL, true)
+
+	return errs.Err()
+}
+
+func CreateSocialLink(input models.CreateSocialLinkInput) error {
+	return socialLink(&input.Platform, &input.URL, input.Icon)
+}
+
+func UpdateSocialLink(input models.UpdateSocialLinkInput) error {
+	return socialLink(input.Platform, input.URL, input.Icon)
+}
+
+func socialLink(platform, linkURL, icon *string) error {
+	var errs Errors
+
+	errs.Required("input.platform", platform, 100)
+	errs.Required("input.url", linkURL, 500)
+	errs.URL("input.url", linkURL, false)
+	errs.MaxLength("input.icon", icon, 100)
+
+	return errs.Err()
+}
+
+// Skill inputs
+func CreateSkill(input models.CreateSkillInput) error {
+	return skill(&input.Name, &input.Category, &input.Level, input.IconURL)
+}
+
+func UpdateSkill(input models.UpdateSkillInput) error {
+	return skill(input.Name, input.Category, input.Level, input.IconURL)
+}
+
+func skill(name, category *string, level *int, iconURL *string) error {
+	var errs Errors
+
+	errs.Required("input.name", name, 255)
+	errs.Required("input.category", category, 255)
+	if level != nil && (*level < 1 || *level > 10) {
+		errs.Add("input.level", "must be between 1 and 10")
+	}
+	errs.MaxLength("input.iconUrl", iconURL, 500)
+	errs.URL("input.iconUrl", iconURL, true)
+
+	return errs.Err()
+}
+
+// Experience inputs
+func CreateExperience(input models.CreateExperienceInput) error {
+	return experience(&input.Company, &input.Position, &input.StartDate, input.EndDate, input.Technologies)
+}
+
+func UpdateExperience(input models.UpdateExperienceInput) error {
+	return experience(input.Company, input.Position, input.StartDate, input.EndDate, input.Technologies)
+}
+
+func experience(company, position, startDate, endDate *string, technologies []string) error {
+	var errs Errors
+
+	errs.Required("input.company", company, 255)
+	errs.Required("input.position", position, 255)
+	errs.Required("input.startDate", startDate, 20)
+	errs.MaxLength("input.endDate", endDate, 20)
+	for i, technology := range technologies {
+		if technology == "" {
+			errs.Add(fmt.Sprintf("input.technologies[%d]", i), "must not be empty")
+		}
+	}
+
+	return errs.Err()
+}
+
+// Media inputs
+const MaxAltTextLength = 500
+
+func UploadMedia(alt *string) error {
+	var errs Errors
+	errs.MaxLength("alt", alt, MaxAltTextLength)
+	return errs.Err()
+}
+
+// MediaFile reports why the uploaded file was rejected as a violation of the file argument
+func MediaFile(err error) error {
+	var errs Errors
+	errs.Add("file", "%s", err.Error())
+	return errs.Err()
+}
+
+// Tag management inputs
+const MaxTagDescriptionLength = 2000
+
+func RenameTag(name string) error {
+	var errs Errors
+	errs.Required("name", &name, MaxTagLength)
+	if strings.TrimSpace(name) != "" && slug.Tag(name) == "" {
+		errs.Add("name", "must contain a letter or digit")
+	}
+	return errs.Err()
+}
+
+func MergeTags(sources []string, target string) error {
+	var errs Errors
+	if len(sources) == 0 {
+		errs.Add("sources", "must not be empty")
+	}
+	if len(sources) > MaxBulkItems {
+		errs.Add("sources", "must have at most %d items", MaxBulkItems)
+	}
+	errs.Required("target", &target, MaxTagLength)
+	return errs.Err()
+}
+
+func DescribeTag(description *string) error {
+	var errs Errors
+	errs.MaxLength("description", description, MaxTagDescriptionLength)
+	return errs.Err()
+}
+
+// Code category inputs
+func CreateCodeCategory(input models.CreateCodeCategoryInput) error {
+	return codeCategory(&input.Name, input.Slug, input.Description, input.Color, input.Icon)
+}
+
+func UpdateCodeCategory(input models.UpdateCodeCategoryInput) error {
+	return codeCategory(input.Name, input.Slug, input.Description, input.Color, input.Icon)
+}
+
+func codeCategory(name, categorySlug, description, color, icon *string) error {
+	var errs Errors
+	errs.Required("input.name", name, 100)
+	if categorySlug != nil && len(*categorySlug) > 100 {
+		errs.Add("input.slug", "must be at most 100 characters")
+	} else {
+		errs.Slug("input.slug", categorySlug)
+	}
+	errs.MaxLength("input.description", description, 2000)
+	errs.Color("input.color", color)
+	errs.MaxLength("input.icon", icon, 10)
+	return errs.Err()
+}
+
+// Series inputs
+const (
+	MaxSeriesDescriptionLength = 2000
+	MaxSeriesItems             = 100
+)
+
+func CreateSeries(input models.CreateSeriesInput) error {
+	return series(&input.Title, input.Slug, input.Description)
+}
+
+func UpdateSeries(input models.UpdateSeriesInput) error {
+	return series(input.Title, input.Slug, input.Description)
+}
+
+func series(title, seriesSlug, description *string) error {
+	var errs Errors
+	errs.Required("input.title", title, 255)
+	if seriesSlug != nil && len(*seriesSlug) > 255 {
+		errs.Add("input.slug", "must be at most 255 characters")
+	} else {
+		errs.Slug("input.slug", seriesSlug)
+	}
+	errs.MaxLength("input.description", description, MaxSeriesDescriptionLength)
+	return errs.Err()
+}
+
+// SetSeriesItems checks the item IDs of a series. An empty list empties the series.
+func SetSeriesItems(itemIDs []string) error {
+	var errs Errors
+	if len(itemIDs) > MaxSeriesItems {
+		errs.Add("itemIds", "must have at most %d items", MaxSeriesItems)
+	}
+	seen := map[string]bool{}
+	for _, id := range itemIDs {
+		if seen[id] {
+			errs.Add("itemIds", "contains %q more than once", id)
+		}
+		seen[id] = true
+	}
+	return errs.Err()
+}
+
+// ContentArchive checks the arguments of contentArchive
+func ContentArchive(year, month, limit, offset *int) error {
+	var errs Errors
+	if month != nil && year == nil {
+		errs.Add("month", "requires year")
+	}
+	if month != nil && (*month < 1 || *month > 12) {
+		errs.Add("month", "must be between 1 and 12")
+	}
+	if year != nil && (*year < 1 || *year > 9999) {
+		errs.Add("year", "must be between 1 and 9999")
+	}
+	if limit != nil && (*limit < 1 || *limit > 100) {
+		errs.Add("limit", "must be between 1 and 100")
+	}
+	if offset != nil && *offset < 0 {
+		errs.Add("offset", "must not be negative")
+	}
+	return errs.Err()
+}
+
+// RelatedContent checks the arguments of relatedContent
+func RelatedContent(monologueID, blogPostID *string, limit *int) error {
+	var errs Errors
+	if (monologueID == nil) == (blogPostID == nil) {
+		errs.Add("monologueId", "exactly one of monologueId and blogPostId must be set")
+	}
+	if limit != nil && (*limit < 1 || *limit > 50) {
+		errs.Add("limit", "must be between 1 and 50")
+	}
+	return errs.Err()
+}
+
+// PreviewToken checks the lifetime of a preview token, in minutes
+func PreviewToken(expiresInMinutes *int, maxMinutes int) error {
+	var errs Errors
+	if expiresInMinutes != nil && (*expiresInMinutes < 1 || *expiresInMinutes > maxMinutes) {
+		errs.Add("expiresInMinutes", "must be between 1 and %d", maxMinutes)
+	}
+	return errs.Err()
+}
+
+// ScheduleContent checks that publishAt is an RFC 3339 time in the future
+func ScheduleContent(publishAt string) error {
+	var errs Errors
+	at, err := time.Parse(time.RFC3339, publishAt)
+	if err != nil {
+		errs.Add("publishAt", "must be an RFC 3339 date-time, e.g. 2025-01-02T15:04:05+09:00")
+	} else if !at.After(time.Now()) {
+		errs.Add("publishAt", "must be in the future")
+	}
+	return errs.Err()
+}
+
+// Bulk operation inputs
+const MaxBulkItems = 100
+
+func BulkIDs(ids []string) error {
+	var errs Errors
+	bulkIDs(&errs, ids)
+	return errs.Err()
+}
+
+func BulkTags(ids []string, tags []string) error {
+	var errs Errors
+	bulkIDs(&errs, ids)
+	if len(tags) == 0 {
+		errs.Add("tags", "must not be empty")
+	}
+	errs.Tags("tags", tags)
+	return errs.Err()
+}
+
+func bulkIDs(errs *Errors, ids []string) {
+	if len(ids) == 0 {
+		errs.Add("ids", "must not be empty")
+	}
+	if len(ids) > MaxBulkItems {
+		errs.Add("ids", "must have at most %d items", MaxBulkItems)
+	}
+}
@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/naoya0117/portfolio-v2025-api/internal/locale"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Limits shared by the content inputs
+const (
+	MaxTags                   = 10
+	MaxTagLength              = 50
+	MaxBlogContentLength      = 200000
+	MaxMonologueContentLength = 20000
+	MaxCodeSnippetLength      = 50000
+	MaxURLLength              = 2048
+)
+
+var (
+	slugRegex         = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	codeLanguageRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9+#._-]*$`)
+	colorRegex        = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// FieldError is a single violation for the input field at Field (e.g. "input.tags[2]")
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Errors collects every violation found in an input so they can be reported together
+type Errors []FieldError
+
+func (e *Errors) Add(field, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e Errors) Error() string {
+	parts := make([]string, len(e))
+	for i, fieldErr := range e {
+		parts[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// Err returns nil when there are no violations, otherwise a GraphQL error that lists
+// every violation in its extensions under "fieldErrors"
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	fieldErrors := make([]map[string]interface{}, len(e))
+	for i, fieldErr := range e {
+		fieldErrors[i] = map[string]interface{}{
+			"field":   fieldErr.Field,
+			"message": fieldErr.Message,
+		}
+	}
+
+	return &gqlerror.Error{
+		Err:     e,
+		Message: e.Error(),
+		Extensions: map[string]interface{}{
+			"code":        "VALIDATION_FAILED",
+			"fieldErrors": fieldErrors,
+		},
+	}
+}
+
+// Required checks that a field being set is not blank and fits in maxLen characters.
+// A nil value means the field is not being changed and is skipped.
+func (e *Errors) Required(field string, value *string, maxLen int) {
+	if value != nil && strings.TrimSpace(*value) == "" {
+		e.Add(field, "must not be empty")
+		return
+	}
+	e.MaxLength(field, value, maxLen)
+}
+
+// MaxLength checks the length in characters, matching PostgreSQL VARCHAR semantics
+func (e *Errors) MaxLength(field string, value *string, maxLen int) {
+	if value != nil && utf8.RuneCountInString(*value) > maxLen {
+		e.Add(field, "must be at most %d characters", maxLen)
+	}
+}
+
+// Slug checks the format of a URL slug: lowercase letters, digits and single hyphens
+func (e *Errors) Slug(field string, value *string) {
+	if value == nil {
+		return
+	}
+	if !slugRegex.MatchString(*value) {
+		e.Add(field, "must contain only lowercase letters, digits and single hyphens")
+		return
+	}
+	e.MaxLength(field, value, 500)
+}
+
+// Locale checks that value is a BCP 47 language tag such as "ja" or "en-US"
+func (e *Errors) Locale(field string, value *string) {
+	if value == nil {
+		return
+	}
+	if _, err := locale.Normalize(*value); err != nil {
+		e.Add(field, "must be a language tag such as \"ja\" or \"en-US\"")
+		return
+	}
+	e.MaxLength(field, value, 35)
+}
+
+// URL checks that value is an absolute http(s) URL. Root-relative paths are accepted
+// when allowRelative is set, for assets served by the frontend itself.
+func (e *Errors) URL(field string, value *string, allowRelative bool) {
+	if value == nil || *value == "" {
+		return
+	}
+	if utf8.RuneCountInString(*value) > MaxURLLength {
+		e.Add(field, "must be at most %d characters", MaxURLLength)
+		return
+	}
+	if allowRelative && strings.HasPrefix(*value, "/") && !strings.HasPrefix(*value, "//") {
+		return
+	}
+
+	parsed, err := url.Parse(*value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		e.Add(field, "must be an absolute http or https URL")
+	}
+}
+
+// Tags checks the tag count, each tag's length and duplicates
+func (e *Errors) Tags(field string, tags []string) {
+	if len(tags) > MaxTags {
+		e.Add(field, "must have at most %d tags", MaxTags)
+	}
+
+	seen := make(map[string]bool, len(tags))
+	for i, tag := range tags {
+		tagField := fmt.Sprintf("%s[%d]", field, i)
+		trimmed := strings.TrimSpace(tag)
+		switch {
+		case trimmed == "":
+			e.Add(tagField, "must not be empty")
+		case utf8.RuneCountInString(tag) > MaxTagLength:
+			e.Add(tagField, "must be at most %d characters", MaxTagLength)
+		case seen[strings.ToLower(trimmed)]:
+			e.Add(tagField, "duplicate tag %q", tag)
+		}
+		seen[strings.ToLower(trimmed)] = true
+	}
+}
+
+// CodeLanguage checks that a code language is a plain lexer-style identifier such as "go" or "c++"
+func (e *Errors) CodeLanguage(field string, value *string) {
+	if value == nil || *value == "" {
+		return
+	}
+	if !codeLanguageRegex.MatchString(*value) {
+		e.Add(field, "must be a lowercase language identifier such as \"go\" or \"typescript\"")
+		return
+	}
+	e.MaxLength(field, value, 50)
+}
+
+// Color checks that a color is a hex color such as "#00add8". Empty values are allowed.
+func (e *Errors) Color(field string, value *string) {
+	if value != nil && *value != "" && !colorRegex.MatchString(*value) {
+		e.Add(field, "must be a hex color such as \"#00add8\"")
+	}
+}
@@ -1,22 +1,37 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"github.com/rs/cors"
+	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/naoya0117/portfolio-v2025-api/internal/auth"
 	"github.com/naoya0117/portfolio-v2025-api/internal/database"
 	"github.com/naoya0117/portfolio-v2025-api/internal/generated"
+	"github.com/naoya0117/portfolio-v2025-api/internal/highlight"
+	"github.com/naoya0117/portfolio-v2025-api/internal/markdown"
+	"github.com/naoya0117/portfolio-v2025-api/internal/media"
+	"github.com/naoya0117/portfolio-v2025-api/internal/postlinks"
+	"github.com/naoya0117/portfolio-v2025-api/internal/pubsub"
 	"github.com/naoya0117/portfolio-v2025-api/internal/resolvers"
+	"github.com/naoya0117/portfolio-v2025-api/internal/scheduler"
+	"github.com/naoya0117/portfolio-v2025-api/internal/storage"
+	"github.com/naoya0117/portfolio-v2025-api/internal/urlpreview"
 )
 
 const defaultPort = "8080"
@@ -49,6 +64,13 @@ func main() {
 		
 		log.Println("Database tables initialized and migrated")
 		
+		// Links to blog posts are detected in monologue content by the public post URL
+		blogURL := os.Getenv("BLOG_URL")
+		if blogURL == "" {
+			blogURL = "/blog"
+		}
+		db.PostLinks = postlinks.NewDetector(blogURL)
+		
 		// Seed initial data (commented out to start with empty database)
 		// if err := db.SeedData(); err != nil {
 		//	log.Printf("Warning: Failed to seed data: %v", err)
@@ -59,34 +81,6 @@ func main() {
 		log.Println("Using mock data (no database connection)")
 	}
 
-	// Initialize resolver with database connection
-	resolver := &resolvers.Resolver{DB: db}
-
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
-
-	// Create router with debug logging
-	router := mux.NewRouter()
-	
-	// Auth endpoints (no auth middleware)
-	router.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
-		fmt.Printf("[ROUTER] Login handler called: %s %s\n", r.Method, r.URL.Path)
-		auth.LoginHandler(w, r)
-	}).Methods("POST", "OPTIONS")
-	
-	// Public endpoints (no auth required)
-	// Only enable playground in development
-	if os.Getenv("GO_ENV") != "production" {
-		router.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	}
-	router.Handle("/query", srv)
-	
-	// Protected admin endpoints
-	// Only enable admin playground in development
-	if os.Getenv("GO_ENV") != "production" {
-		router.Handle("/admin", auth.AuthMiddleware(playground.Handler("GraphQL playground (Admin)", "/admin/query")))
-	}
-	router.Handle("/admin/query", auth.AuthMiddleware(srv))
-
 	// Get allowed origins from environment or use defaults
 	allowedOrigins := []string{
 		"http://localhost:3000", 
@@ -158,6 +152,99 @@ func main() {
 		Debug:            os.Getenv("GO_ENV") == "development",
 	})
 
+	// URL previews are cached in the database when one is available
+	var previewStore urlpreview.Store
+	if db != nil {
+		previewStore = db
+	}
+
+	// Uploaded media is stored on the local filesystem and served under /media/
+	mediaDir := os.Getenv("MEDIA_DIR")
+	if mediaDir == "" {
+		mediaDir = "uploads"
+	}
+	mediaBaseURL := os.Getenv("MEDIA_BASE_URL")
+	if mediaBaseURL == "" {
+		mediaBaseURL = "/media"
+	}
+	mediaStorage := storage.NewLocal(mediaDir, mediaBaseURL)
+	uploads := media.NewProcessor(mediaStorage)
+
+	// Initialize resolver with database connection, the in-process event broker, the Markdown
+	// renderer, the URL preview cache and the media processor
+	resolver := &resolvers.Resolver{
+		DB:          db,
+		PubSub:      pubsub.New(),
+		Markdown:    markdown.NewRenderer(),
+		URLPreviews: urlpreview.NewService(urlpreview.NewFetcher(), previewStore),
+		Uploads:     uploads,
+	}
+
+	// Publish scheduled content and refresh URL previews in the background. Every
+	// instance runs the scheduler; the database makes sure each item is published only once.
+	if db != nil {
+		contentScheduler := scheduler.New(db, resolver.NotifyPublished)
+		if interval, err := time.ParseDuration(os.Getenv("SCHEDULER_INTERVAL")); err == nil && interval > 0 {
+			contentScheduler.Interval = interval
+		}
+		// Monologues only read stored URL previews; expired ones are fetched again here
+		contentScheduler.Refresher = resolver.URLPreviews
+		go contentScheduler.Run(context.Background())
+	}
+
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers:  resolver,
+		Directives: generated.DirectiveRoot{Admin: resolvers.AdminDirective},
+	}))
+	// Subscriptions over websocket (graphql-transport-ws and legacy graphql-ws)
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			// Browsers do not send CORS preflights for websocket upgrades, so apply the same origin rules here
+			CheckOrigin: c.OriginAllowed,
+		},
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{
+		// Leave room for the other form parts; the processor enforces the file limit itself
+		MaxUploadSize: uploads.MaxSize + 1<<20,
+		MaxMemory:     32 << 20,
+	})
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	// Resolvers share data like the code category tree within one operation
+	srv.AroundOperations(resolvers.RequestCache)
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New[string](100),
+	})
+
+	// Create router with debug logging
+	router := mux.NewRouter()
+	
+	// Auth endpoints (no auth middleware)
+	router.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("[ROUTER] Login handler called: %s %s\n", r.Method, r.URL.Path)
+		auth.LoginHandler(w, r)
+	}).Methods("POST", "OPTIONS")
+	
+	// Public endpoints (no auth required)
+	// Only enable playground in development
+	if os.Getenv("GO_ENV") != "production" {
+		router.Handle("/", playground.Handler("GraphQL playground", "/query"))
+	}
+	router.Handle("/query", srv)
+	router.HandleFunc("/highlight/{theme}.css", highlight.CSSHandler).Methods("GET")
+	router.PathPrefix("/media/").Handler(http.StripPrefix("/media/", mediaStorage.Handler())).Methods("GET", "HEAD")
+	
+	// Protected admin endpoints
+	// Only enable admin playground in development
+	if os.Getenv("GO_ENV") != "production" {
+		router.Handle("/admin", auth.AuthMiddleware(playground.Handler("GraphQL playground (Admin)", "/admin/query")))
+	}
+	router.Handle("/admin/query", auth.AuthMiddleware(srv))
+
 	// Add global request logging
 	finalHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Printf("[SERVER] %s %s from %s\n", r.Method, r.URL.Path, r.RemoteAddr)